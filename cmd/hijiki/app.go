@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+type pathFlags struct {
+	configPath  string
+	envPath     string
+	recordsPath string
+	logPath     string
+	pausePath   string
+}
+
+func registerConfigFlag(flags *flag.FlagSet, paths *pathFlags) {
+	flags.StringVar(&paths.configPath, "config", infrastructure.FindDefaultConfigFile("."), "schedule config file (.json, .yaml, .yml or .toml)")
+}
+
+func registerEnvFlag(flags *flag.FlagSet, paths *pathFlags) {
+	flags.StringVar(&paths.envPath, "env", ".env", "env file with Misskey credentials and values for ${VAR} in the config (optional if set in the environment)")
+}
+
+func registerRecordsFlag(flags *flag.FlagSet, paths *pathFlags) {
+	flags.StringVar(&paths.recordsPath, "records", "post_records.json", "post records file (.json, or .db/.sqlite for SQLite)")
+}
+
+func registerLogFlag(flags *flag.FlagSet, paths *pathFlags) {
+	flags.StringVar(&paths.logPath, "log", "hijiki.log", "log file")
+}
+
+func registerPauseFlag(flags *flag.FlagSet, paths *pathFlags) {
+	flags.StringVar(&paths.pausePath, "pause-file", "pause_state.json", "file that keeps paused schedules across restarts")
+}
+
+func openPostRecordRepository(recordsPath string) (ports.PostRecordRepository, error) {
+	repository, err := infrastructure.OpenPostRecordRepository(recordsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open post records: %w", err)
+	}
+	return repository, nil
+}
+
+func openReadOnlyPostRecordRepository(recordsPath string) (ports.PostRecordRepository, error) {
+	repository, err := infrastructure.OpenReadOnlyPostRecordRepository(recordsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open post records: %w", err)
+	}
+	return repository, nil
+}
+
+func closePostRecordRepository(repository ports.PostRecordRepository) {
+	if closer, ok := repository.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+func newScheduleConfigLoader(paths pathFlags) *infrastructure.ScheduleConfigLoader {
+	loader := infrastructure.NewScheduleConfigLoader(paths.configPath)
+	loader.SetEnvFile(paths.envPath)
+	return loader
+}
+
+func loadSchedulerConfig(paths pathFlags) ([]scheduler.Job, []infrastructure.AccountConfig, error) {
+	scheduleConfigLoader := newScheduleConfigLoader(paths)
+	scheduleConfigs, err := scheduleConfigLoader.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load schedule config: %w", err)
+	}
+
+	accountConfigs, err := scheduleConfigLoader.LoadAccounts()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load account config: %w", err)
+	}
+
+	return createJobsFromScheduleConfigs(scheduleConfigs), accountConfigs, nil
+}
+
+func createPoster(envPath string, accountConfigs []infrastructure.AccountConfig) (ports.Poster, error) {
+	envConfigLoader := infrastructure.NewEnvConfigLoader(envPath)
+	envConfig, err := envConfigLoader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env config: %w", err)
+	}
+
+	accountPosters, err := infrastructure.NewPostersForAccounts(accountConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account posters: %w", err)
+	}
+
+	return infrastructure.NewAccountPoster(infrastructure.NewMisskeyPoster(envConfig), accountPosters), nil
+}
+
+func createDryRunPoster(accountConfigs []infrastructure.AccountConfig) ports.Poster {
+	stdoutPoster := infrastructure.NewStdoutPoster(os.Stdout)
+	accountPosters := make(map[string]ports.Poster, len(accountConfigs))
+	for _, account := range accountConfigs {
+		accountPosters[account.Name] = stdoutPoster
+	}
+	return infrastructure.NewAccountPoster(stdoutPoster, accountPosters)
+}
+
+func createJobsFromScheduleConfigs(configs []infrastructure.ScheduleConfig) []scheduler.Job {
+	jobs := make([]scheduler.Job, 0, len(configs))
+	for _, config := range configs {
+		jobs = append(jobs, scheduler.Job{
+			Source:      config.Source,
+			ID:          config.ID,
+			Schedule:    config.Schedule,
+			Content:     config.Content,
+			Account:     config.Account,
+			SpoilerText: config.SpoilerText,
+			Visibility:  config.Visibility,
+			MediaPaths:  config.MediaPaths,
+		})
+	}
+	return jobs
+}
+
+func printError(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+)
+
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		printError("Usage: hijiki config convert <input> <output> | hijiki config schema")
+		return 2
+	}
+
+	switch args[0] {
+	case "convert":
+		return runConfigConvert(args[1:])
+	case "schema":
+		return runConfigSchema(args[1:])
+	default:
+		printError("Unknown config command: %s", args[0])
+		return 2
+	}
+}
+
+func runConfigConvert(args []string) int {
+	if len(args) != 2 {
+		printError("Usage: hijiki config convert <input> <output>")
+		return 2
+	}
+	inputPath, outputPath := args[0], args[1]
+
+	if _, err := os.Stat(outputPath); err == nil {
+		printError("%s already exists", outputPath)
+		return 1
+	}
+
+	if err := infrastructure.ConvertConfigFile(inputPath, outputPath); err != nil {
+		printError("Failed to convert %s: %v", inputPath, err)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stdout, "Converted %s to %s\n", inputPath, outputPath)
+	return 0
+}
+
+func runConfigSchema(args []string) int {
+	if len(args) != 0 {
+		printError("Usage: hijiki config schema")
+		return 2
+	}
+
+	schema, err := infrastructure.ConfigJSONSchema()
+	if err != nil {
+		printError("Failed to generate schema: %v", err)
+		return 1
+	}
+
+	os.Stdout.Write(schema)
+	return 0
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+const accountCheckTimeout = 30 * time.Second
+
+type misskeyTarget struct {
+	name       string
+	host       string
+	token      string
+	visibility string
+}
+
+type accountCheck struct {
+	name     string
+	host     string
+	account  infrastructure.MisskeyAccount
+	err      error
+	problems []string
+}
+
+func (c accountCheck) fatal() bool {
+	return errors.Is(c.err, infrastructure.ErrCredentialsRejected) || len(c.problems) > 0
+}
+
+func (c accountCheck) String() string {
+	if c.err != nil {
+		return fmt.Sprintf("%s (%s): %v", c.name, c.host, c.err)
+	}
+	maxLength := "no length limit"
+	if c.account.MaxNoteTextLength > 0 {
+		maxLength = fmt.Sprintf("max %d characters", c.account.MaxNoteTextLength)
+	}
+	return fmt.Sprintf("%s: @%s on %s (Misskey %s, %s)", c.name, c.account.Username, c.host, c.account.Version, maxLength)
+}
+
+func runDoctor(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	jobs, accountConfigs, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	checks, err := checkMisskeyAccounts(context.Background(), paths.envPath, accountConfigs, jobs)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.err != nil || len(check.problems) > 0 {
+			failed++
+			printError("NG  %s", check)
+		} else {
+			fmt.Fprintf(os.Stdout, "OK  %s\n", check)
+		}
+		for _, problem := range check.problems {
+			printError("    %s", problem)
+		}
+	}
+
+	if failed > 0 {
+		printError("%d of %d account(s) have problems", failed, len(checks))
+		return 1
+	}
+	fmt.Fprintf(os.Stdout, "%d account(s) OK\n", len(checks))
+	return 0
+}
+
+func checkMisskeyAccounts(ctx context.Context, envPath string, accountConfigs []infrastructure.AccountConfig, jobs []scheduler.Job) ([]accountCheck, error) {
+	envConfig, err := infrastructure.NewEnvConfigLoader(envPath).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env config: %w", err)
+	}
+
+	targets := []misskeyTarget{{
+		name:       infrastructure.DefaultAccountName,
+		host:       envConfig.MisskeyHost,
+		token:      envConfig.MisskeyToken,
+		visibility: envConfig.Visibility,
+	}}
+	for _, account := range accountConfigs {
+		if account.Type == "" || account.Type == "misskey" {
+			targets = append(targets, misskeyTarget{
+				name:       account.Name,
+				host:       account.Host,
+				token:      account.Token,
+				visibility: account.Visibility,
+			})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, accountCheckTimeout)
+	defer cancel()
+
+	checks := make([]accountCheck, 0, len(targets))
+	for _, target := range targets {
+		check := accountCheck{name: target.name, host: target.host}
+		check.account, check.err = infrastructure.NewMisskeyInspector(target.host, target.token).Inspect(ctx)
+		if check.err == nil {
+			for _, job := range jobs {
+				if defaultAccountName(job.Account) != target.name {
+					continue
+				}
+				post := job.Post()
+				if post.Visibility == "" {
+					post.Visibility = target.visibility
+				}
+				for _, problem := range check.account.Check(post) {
+					check.problems = append(check.problems, fmt.Sprintf("schedule %s: %s", job.ID, problem))
+				}
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
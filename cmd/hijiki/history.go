@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type historyEntry struct {
+	ScheduleID  string    `json:"scheduleId"`
+	Account     string    `json:"account"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Status      string    `json:"status"`
+	LatencyMS   int64     `json:"latencyMs"`
+	TextHash    string    `json:"textHash"`
+	NoteID      string    `json:"noteId"`
+	Error       string    `json:"error"`
+}
+
+func runHistory(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	registerRecordsFlag(flags, &paths)
+	scheduleID := flags.String("id", "", "only show this schedule")
+	since := flags.String("since", "", "only show attempts since an age (7d, 12h) or a date (2006-01-02 or RFC 3339)")
+	status := flags.String("status", "", "only show attempts with this status (posted or failed)")
+	limit := flags.Int("limit", 50, "maximum number of attempts to show (0 for all)")
+	format := flags.String("format", "table", "output format: table, json or csv")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	query := ports.PostHistoryQuery{ScheduleID: *scheduleID, Status: domain.PostStatus(*status), Limit: *limit}
+	if *since != "" {
+		sinceTime, err := parseSince(*since, time.Now())
+		if err != nil {
+			printError("Invalid --since: %v", err)
+			return 2
+		}
+		query.Since = sinceTime
+	}
+	switch query.Status {
+	case "", domain.PostStatusPosted, domain.PostStatusFailed:
+	default:
+		printError("Invalid --status: %s (expected posted or failed)", *status)
+		return 2
+	}
+
+	repository, err := openPostRecordRepository(paths.recordsPath)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+	defer closePostRecordRepository(repository)
+
+	history, ok := repository.(ports.PostHistory)
+	if !ok {
+		printError("%s does not keep post history", paths.recordsPath)
+		return 1
+	}
+	records, err := history.Query(query)
+	if err != nil {
+		printError("Failed to read post history: %v", err)
+		return 1
+	}
+
+	entries := make([]historyEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, historyEntry{
+			ScheduleID:  record.ScheduleID,
+			Account:     defaultAccountName(record.Account),
+			AttemptedAt: record.LastPostedAt,
+			Status:      string(record.Status),
+			LatencyMS:   record.Latency.Milliseconds(),
+			TextHash:    record.TextHash,
+			NoteID:      record.NoteID,
+			Error:       record.Error,
+		})
+	}
+
+	switch *format {
+	case "table":
+		err = writeHistoryTable(os.Stdout, entries)
+	case "json":
+		err = writeJSON(os.Stdout, entries)
+	case "csv":
+		err = writeHistoryCSV(os.Stdout, entries)
+	default:
+		printError("Unknown format: %s", *format)
+		return 2
+	}
+	if err != nil {
+		printError("Failed to write output: %v", err)
+		return 1
+	}
+	return 0
+}
+
+func writeHistoryTable(output io.Writer, entries []historyEntry) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tID\tACCOUNT\tSTATUS\tLATENCY\tNOTE\tERROR")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%dms\t%s\t%s\n",
+			entry.AttemptedAt.Format(time.RFC3339),
+			entry.ScheduleID,
+			entry.Account,
+			entry.Status,
+			entry.LatencyMS,
+			defaultString(entry.NoteID, "-"),
+			summarizeText(entry.Error, 60),
+		)
+	}
+	return writer.Flush()
+}
+
+func writeHistoryCSV(output io.Writer, entries []historyEntry) error {
+	writer := csv.NewWriter(output)
+	writer.Write([]string{"attemptedAt", "scheduleId", "account", "status", "latencyMs", "textHash", "noteId", "error"})
+	for _, entry := range entries {
+		writer.Write([]string{
+			entry.AttemptedAt.Format(time.RFC3339),
+			entry.ScheduleID,
+			entry.Account,
+			entry.Status,
+			strconv.FormatInt(entry.LatencyMS, 10),
+			entry.TextHash,
+			entry.NoteID,
+			entry.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if age, err := parseAge(value); err == nil {
+		return now.Add(-age), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither an age like 7d nor a date", value)
+}
+
+func parseAge(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 || count > int(math.MaxInt64/int64(24*time.Hour)) {
+			return 0, fmt.Errorf("invalid age: %s", value)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age: %s", value)
+	}
+	return age, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "7d", expected: 7 * 24 * time.Hour},
+		{value: "0d", expected: 0},
+		{value: "90m", expected: 90 * time.Minute},
+		{value: "1h30m", expected: 90 * time.Minute},
+		{value: "", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "7", wantErr: true},
+		{value: "-1d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "1.5d", wantErr: true},
+		{value: "7days", wantErr: true},
+		{value: "999999999d", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			age, err := parseAge(test.value)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, age)
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{value: "7d", expected: time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)},
+		{value: "2h", expected: time.Date(2026, 2, 10, 10, 0, 0, 0, time.UTC)},
+		{value: "2026-02-01", expected: time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2026-02-01T09:00:00+09:00", expected: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "", wantErr: true},
+		{value: "yesterday", wantErr: true},
+		{value: "-7d", wantErr: true},
+		{value: "2026-13-01", wantErr: true},
+		{value: "2026/02/01", wantErr: true},
+		{value: "2026-02-01 09:00", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			since, err := parseSince(test.value, now)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.expected.Equal(since), "expected %s, got %s", test.expected, since)
+		})
+	}
+}
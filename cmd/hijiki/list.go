@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+)
+
+func runList(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	format := flags.String("format", "table", "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	jobs, _, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	now := infrastructure.NewRealClock().Now()
+
+	if *format == "json" {
+		type listedSchedule struct {
+			ID       string    `json:"id"`
+			Schedule string    `json:"schedule"`
+			Account  string    `json:"account"`
+			NextTime time.Time `json:"nextTime"`
+			Content  string    `json:"content"`
+			Source   string    `json:"source"`
+		}
+		listed := make([]listedSchedule, 0, len(jobs))
+		for _, job := range jobs {
+			listed = append(listed, listedSchedule{
+				ID:       job.ID,
+				Schedule: job.Schedule.String(),
+				Account:  defaultAccountName(job.Account),
+				NextTime: job.Schedule.NextTime(now),
+				Content:  job.Content,
+				Source:   job.Source,
+			})
+		}
+		if err := writeJSON(os.Stdout, listed); err != nil {
+			printError("Failed to write output: %v", err)
+			return 1
+		}
+		return 0
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tSCHEDULE\tACCOUNT\tNEXT\tSOURCE\tCONTENT")
+	for _, job := range jobs {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			job.ID,
+			job.Schedule.String(),
+			defaultAccountName(job.Account),
+			job.Schedule.NextTime(now).Format(time.RFC3339),
+			job.Source,
+			summarizeText(job.Content, 40),
+		)
+	}
+	if err := writer.Flush(); err != nil {
+		printError("Failed to write output: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -1,75 +1,68 @@
 package main
 
 import (
-	"context"
-	"log"
+	"fmt"
+	"io"
 	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
-	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+	"strings"
 )
 
-func main() {
-	logFile := setupLogger()
-	defer logFile.Close()
-
-	envConfigLoader := infrastructure.NewEnvConfigLoader(".env")
-	envConfig, err := envConfigLoader.Load()
-	if err != nil {
-		log.Fatalf("Failed to load env config: %v", err)
-	}
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(args []string) int
+}
 
-	scheduleConfigLoader := infrastructure.NewScheduleConfigLoader("config.json")
-	scheduleConfigs, err := scheduleConfigLoader.Load()
-	if err != nil {
-		log.Fatalf("Failed to load schedule config: %v", err)
+func commands() []command {
+	return []command{
+		{"run", "run [flags]", "start the scheduler daemon (default)", runDaemon},
+		{"validate", "validate [flags]", "check config.json and .env without posting", runValidate},
+		{"doctor", "doctor [flags]", "verify Misskey credentials and check contents against instance limits", runDoctor},
+		{"list", "list [flags]", "list configured schedules", runList},
+		{"next", "next [flags]", "show upcoming posts", runNext},
+		{"post", "post [flags] <id>", "post a schedule immediately", runPost},
+		{"pause", "pause [flags] [--all | <id>...]", "pause schedules, or show what is paused", runPause},
+		{"resume", "resume [flags] [--all | <id>...]", "resume paused schedules", runResume},
+		{"history", "history [flags]", "show past post attempts", runHistory},
+		{"records", "records import [flags] <json>", "import post_records.json into a SQLite database", runRecords},
+		{"simulate", "simulate --from DATE --to DATE [flags]", "print the posts a date range would produce", runSimulate},
+		{"config", "config convert <input> <output> | schema", "convert the config between JSON, YAML and TOML, or print its JSON Schema", runConfig},
 	}
+}
 
-	clock := infrastructure.NewRealClock()
-	repository := infrastructure.NewJSONPostRecordRepository("post_records.json")
-	poster := infrastructure.NewMisskeyPoster(envConfig)
-
-	jobs := createJobsFromScheduleConfigs(scheduleConfigs)
-
-	s := scheduler.New(clock, repository, poster, jobs)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+func main() {
+	os.Exit(dispatch(os.Args[1:]))
+}
 
-	go handleShutdown(cancel)
+func dispatch(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runDaemon(args)
+	}
 
-	log.Printf("Scheduler started with %d job(s)", len(jobs))
-	s.Run(ctx)
-	log.Println("Scheduler stopped")
-}
+	if args[0] == "help" {
+		printUsage(os.Stdout)
+		return 0
+	}
 
-func setupLogger() *os.File {
-	logFile, err := os.OpenFile("hijiki.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-	if err != nil {
-		log.Fatal("Failed to open log file: ", err)
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
 	}
-	log.SetOutput(logFile)
-	return logFile
-}
 
-func handleShutdown(cancel context.CancelFunc) {
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
-	log.Println("Shutdown signal received")
-	cancel()
+	fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", args[0])
+	printUsage(os.Stderr)
+	return 2
 }
 
-func createJobsFromScheduleConfigs(configs []infrastructure.ScheduleConfig) []scheduler.Job {
-	jobs := make([]scheduler.Job, 0, len(configs))
-	for _, config := range configs {
-		jobs = append(jobs, scheduler.Job{
-			ID:       config.ID,
-			Schedule: config.Schedule,
-			Content:  config.Content,
-		})
+func printUsage(output io.Writer) {
+	fmt.Fprintln(output, "Usage: hijiki <command> [flags]")
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(output, "  %-40s %s\n", cmd.usage, cmd.description)
 	}
-	return jobs
+	fmt.Fprintln(output)
+	fmt.Fprintln(output, "Run 'hijiki <command> -h' for the flags of each command.")
 }
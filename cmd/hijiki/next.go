@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+func runNext(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("next", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	count := flags.Int("n", 10, "number of upcoming posts to show")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *count < 1 {
+		printError("Invalid -n: %d (must be at least 1)", *count)
+		return 2
+	}
+
+	jobs, _, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	now := infrastructure.NewRealClock().Now()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tIN\tID\tACCOUNT\tCONTENT")
+	for _, upcoming := range scheduler.Upcoming(jobs, now, *count) {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			upcoming.FireTime.Format(time.RFC3339),
+			upcoming.FireTime.Sub(now).Round(time.Minute),
+			upcoming.Job.ID,
+			defaultAccountName(upcoming.Job.Account),
+			summarizeText(upcoming.Job.Content, 40),
+		)
+	}
+	if err := writer.Flush(); err != nil {
+		printError("Failed to write output: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+func runPause(args []string) int {
+	return runPauseCommand("pause", args, true)
+}
+
+func runResume(args []string) int {
+	return runPauseCommand("resume", args, false)
+}
+
+func runPauseCommand(name string, args []string, paused bool) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	registerPauseFlag(flags, &paths)
+	all := flags.Bool("all", false, name+" every schedule")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *all && flags.NArg() > 0 {
+		printError("Usage: hijiki %s [flags] [--all | <id>...]", name)
+		return 2
+	}
+
+	jobs, _, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+	s := scheduler.New(infrastructure.NewRealClock(), nil, nil, jobs)
+	s.SetPauseStore(infrastructure.NewJSONPauseStore(paths.pausePath))
+
+	if !*all && flags.NArg() == 0 {
+		if !paused {
+			printError("Usage: hijiki resume [flags] [--all | <id>...]")
+			return 2
+		}
+		return printPauseState(s)
+	}
+
+	if *all {
+		apply := s.ResumeAll
+		if paused {
+			apply = s.PauseAll
+		}
+		if err := apply(); err != nil {
+			printError("Failed to update %s: %v", paths.pausePath, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stdout, "all schedules %s\n", pausedLabel(paused))
+		return 0
+	}
+
+	for _, id := range flags.Args() {
+		if _, exists := s.FindJob(id); !exists {
+			printError("Unknown schedule: %s", id)
+			return 1
+		}
+	}
+	for _, id := range flags.Args() {
+		apply := s.Resume
+		if paused {
+			apply = s.Pause
+		}
+		if err := apply(id); err != nil {
+			printError("Failed to update %s: %v", paths.pausePath, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stdout, "%s %s\n", id, pausedLabel(paused))
+	}
+	return 0
+}
+
+func printPauseState(s *scheduler.Scheduler) int {
+	state, err := s.PauseState()
+	if err != nil {
+		printError("Failed to read pause state: %v", err)
+		return 1
+	}
+	if state.All {
+		fmt.Fprintln(os.Stdout, "all schedules paused")
+	}
+	ids := state.PausedSchedules()
+	if !state.All && len(ids) == 0 {
+		fmt.Fprintln(os.Stdout, "no schedules paused")
+	}
+	for _, id := range ids {
+		fmt.Fprintf(os.Stdout, "%s paused\n", id)
+	}
+	return 0
+}
+
+func pausedLabel(paused bool) string {
+	if paused {
+		return "paused"
+	}
+	return "resumed"
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+func runPost(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("post", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	registerRecordsFlag(flags, &paths)
+	registerPauseFlag(flags, &paths)
+	force := flags.Bool("force", false, "post even if the schedule has already posted in the current period, is paused or is muted")
+	dryRun := flags.Bool("dry-run", false, "print the post to stdout instead of posting")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printError("Usage: hijiki post [flags] <id>")
+		return 2
+	}
+	scheduleID := flags.Arg(0)
+
+	jobs, accountConfigs, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	if !*dryRun {
+		lock, err := infrastructure.LockFile(paths.recordsPath + ".lock")
+		if err != nil {
+			printError("%v; use POST /schedules/%s/trigger on the admin API while hijiki run is running", err, scheduleID)
+			return 1
+		}
+		defer lock.Unlock()
+	}
+
+	openRepository := openPostRecordRepository
+	if *dryRun {
+		openRepository = openReadOnlyPostRecordRepository
+	}
+	repository, err := openRepository(paths.recordsPath)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+	defer closePostRecordRepository(repository)
+
+	var poster ports.Poster
+	if *dryRun {
+		repository = infrastructure.NewInMemoryPostRecordRepository(repository)
+		poster = createDryRunPoster(accountConfigs)
+	} else {
+		poster, err = createPoster(paths.envPath, accountConfigs)
+		if err != nil {
+			printError("%v", err)
+			return 1
+		}
+	}
+
+	muteWindows, err := newScheduleConfigLoader(paths).LoadMuteWindows()
+	if err != nil {
+		printError("Failed to load mute windows: %v", err)
+		return 1
+	}
+
+	s := scheduler.New(infrastructure.NewRealClock(), repository, poster, jobs)
+	s.SetPauseStore(infrastructure.NewJSONPauseStore(paths.pausePath))
+	s.SetMuteWindows(muteWindows)
+	before, err := repository.Find(scheduleID)
+	if err != nil {
+		printError("Failed to read post records: %v", err)
+		return 1
+	}
+
+	if err := s.Trigger(context.Background(), scheduleID, *force); err != nil {
+		printError("Failed to post %s: %v", scheduleID, err)
+		return 1
+	}
+
+	after, err := repository.Find(scheduleID)
+	if err != nil {
+		printError("Failed to read post records: %v", err)
+		return 1
+	}
+	if after.LastPostedAt.Equal(before.LastPostedAt) {
+		fmt.Fprintf(os.Stdout, "%s was not posted: already posted in this period at %s (use --force to post anyway)\n", scheduleID, before.LastPostedAt.Format("2006-01-02 15:04:05"))
+		return 0
+	}
+
+	fmt.Fprintf(os.Stdout, "%s posted\n", scheduleID)
+	return 0
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+)
+
+func runRecords(args []string) int {
+	if len(args) == 0 {
+		printError("Usage: hijiki records import [flags] <post_records.json>")
+		return 2
+	}
+
+	switch args[0] {
+	case "import":
+		return runRecordsImport(args[1:])
+	default:
+		printError("Unknown records command: %s", args[0])
+		return 2
+	}
+}
+
+func runRecordsImport(args []string) int {
+	flags := flag.NewFlagSet("records import", flag.ContinueOnError)
+	databasePath := flags.String("records", "post_records.db", "SQLite post records database to import into")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		printError("Usage: hijiki records import [flags] <post_records.json>")
+		return 2
+	}
+	jsonPath := flags.Arg(0)
+
+	if !infrastructure.IsSQLiteRecordsPath(*databasePath) {
+		printError("%s is not a SQLite database (.db, .sqlite or .sqlite3)", *databasePath)
+		return 2
+	}
+
+	repository, err := infrastructure.NewSQLitePostRecordRepository(*databasePath)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+	defer repository.Close()
+
+	imported, err := repository.ImportJSON(jsonPath)
+	if err != nil {
+		printError("Failed to import %s: %v", jsonPath, err)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stdout, "Imported %d record(s) from %s into %s\n", imported, jsonPath, *databasePath)
+	return 0
+}
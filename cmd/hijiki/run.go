@@ -0,0 +1,448 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/adminapi"
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/metrics"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+type daemon struct {
+	paths        pathFlags
+	dryRun       bool
+	metrics      *metrics.Registry
+	alerts       *usecases.FailureAlertUseCase
+	watcher      *infrastructure.FileWatcher
+	logsToStderr bool
+}
+
+func runDaemon(args []string) int {
+	var d daemon
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	registerConfigFlag(flags, &d.paths)
+	registerEnvFlag(flags, &d.paths)
+	registerRecordsFlag(flags, &d.paths)
+	registerLogFlag(flags, &d.paths)
+	registerPauseFlag(flags, &d.paths)
+	flags.BoolVar(&d.dryRun, "dry-run", false, "print posts to stdout instead of posting and do not persist post records")
+	watchInterval := flags.Duration("watch-interval", 5*time.Second, "how often to check config and env files for changes (0 disables; SIGHUP always reloads)")
+	workers := flags.Int("workers", scheduler.DefaultWorkers, "maximum number of posts sent at the same time")
+	jobTimeout := flags.Duration("job-timeout", scheduler.DefaultJobTimeout, "give up on a post after this long")
+	drainTimeout := flags.Duration("drain-timeout", scheduler.DefaultDrainTimeout, "on shutdown, wait this long for in-flight posts before interrupting them")
+	adminAddr := flags.String("admin-addr", "", "serve the admin API on a loopback address like 127.0.0.1:8787 or on unix:/path/to/socket (default: disabled)")
+	adminTokenFile := flags.String("admin-token-file", "", "file containing the admin API token (default: $HIJIKI_ADMIN_TOKEN)")
+	healthMaxStall := flags.Duration("health-max-stall", adminapi.DefaultMaxStall, "report unhealthy at /healthz when the scheduler loop has not run for this long")
+	healthMaxFailures := flags.Int("health-max-failures", adminapi.DefaultMaxFailures, "report unhealthy at /healthz when this many posts in a row failed (0 disables)")
+	metricsAddr := flags.String("metrics-addr", "", "serve Prometheus metrics at /metrics on this address, e.g. 127.0.0.1:9464 (default: disabled)")
+	skipAccountCheck := flags.Bool("skip-account-check", false, "do not verify Misskey credentials and instance limits at startup")
+	historyRetention := flags.String("history-retention", "", "delete post history older than this age, e.g. 90d (default: keep forever)")
+	logFormat := flags.String("log-format", "text", "log format: text or json")
+	logLevel := flags.String("log-level", "info", "minimum log level: debug, info, warn or error")
+	logOutput := flags.String("log-output", "file", "where to write logs: file, stderr or both (--dry-run defaults to stderr)")
+	logMaxSize := flags.String("log-max-size", "10MB", "rotate the log file when it grows past this size, e.g. 512KB or 10MB (0 disables)")
+	logMaxBackups := flags.Int("log-max-backups", 5, "number of rotated log files to keep")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *workers < 1 {
+		printError("Invalid --workers: %d", *workers)
+		return 2
+	}
+	if *jobTimeout <= 0 {
+		printError("Invalid --job-timeout: %s", *jobTimeout)
+		return 2
+	}
+	if *drainTimeout < 0 {
+		printError("Invalid --drain-timeout: %s", *drainTimeout)
+		return 2
+	}
+	if *healthMaxStall <= 0 {
+		printError("Invalid --health-max-stall: %s", *healthMaxStall)
+		return 2
+	}
+	if *healthMaxFailures < 0 {
+		printError("Invalid --health-max-failures: %d", *healthMaxFailures)
+		return 2
+	}
+	var retention time.Duration
+	if *historyRetention != "" {
+		var err error
+		if retention, err = parseAge(*historyRetention); err != nil || retention <= 0 {
+			printError("Invalid --history-retention: %s", *historyRetention)
+			return 2
+		}
+	}
+
+	maxSize, err := parseByteSize(*logMaxSize)
+	if err != nil {
+		printError("Invalid --log-max-size: %s", *logMaxSize)
+		return 2
+	}
+	if *logMaxBackups < 0 {
+		printError("Invalid --log-max-backups: %d", *logMaxBackups)
+		return 2
+	}
+	if d.dryRun && !flagPassed(flags, "log-output") {
+		*logOutput = "stderr"
+	}
+	logger, logCloser, err := infrastructure.NewLogger(infrastructure.LogOptions{
+		Format:     *logFormat,
+		Level:      *logLevel,
+		Output:     *logOutput,
+		FilePath:   d.paths.logPath,
+		MaxSize:    maxSize,
+		MaxBackups: *logMaxBackups,
+	})
+	if err != nil {
+		printError("%v", err)
+		return 2
+	}
+	defer logCloser.Close()
+	slog.SetDefault(logger)
+	d.logsToStderr = *logOutput != "file"
+	if d.dryRun {
+		slog.Info("Dry-run mode: posts are printed to stdout and post records are not persisted")
+	}
+
+	clock := infrastructure.NewRealClock()
+	if *metricsAddr != "" {
+		d.metrics = metrics.NewRegistry()
+	}
+
+	config, err := d.load(clock)
+	if err != nil {
+		return d.failStart(err)
+	}
+	if !d.dryRun && !*skipAccountCheck {
+		if err := d.verifyAccounts(config); err != nil {
+			return d.failStart(err)
+		}
+	}
+
+	if !d.dryRun {
+		lock, err := infrastructure.LockFile(d.paths.recordsPath + ".lock")
+		if err != nil {
+			return d.failStart(err)
+		}
+		defer lock.Unlock()
+	}
+
+	openRepository := openPostRecordRepository
+	if d.dryRun {
+		openRepository = openReadOnlyPostRecordRepository
+	}
+	repository, err := openRepository(d.paths.recordsPath)
+	if err != nil {
+		return d.failStart(err)
+	}
+	defer closePostRecordRepository(repository)
+	if d.dryRun {
+		repository = infrastructure.NewInMemoryPostRecordRepository(repository)
+	}
+
+	s := scheduler.New(clock, repository, config.poster, config.jobs)
+	s.SetWorkers(*workers)
+	s.SetJobTimeout(*jobTimeout)
+	s.SetDrainTimeout(*drainTimeout)
+	s.SetPauseStore(infrastructure.NewJSONPauseStore(d.paths.pausePath))
+	s.SetMuteWindows(config.muteWindows)
+	alerts := usecases.NewFailureAlertUseCase(clock)
+	alerts.SetConfig(config.alerts)
+	alerts.SetPoster(config.alertPoster)
+	s.SetFailureAlerts(alerts)
+	d.alerts = alerts
+	notifier := infrastructure.NewSystemdNotifier()
+	if notifier.Enabled() {
+		watchdog, watchdogEnabled := notifier.WatchdogInterval()
+		s.SetHeartbeat(watchdog/2, func(now time.Time) {
+			states := []string{"STATUS=" + describeNextPost(s, now)}
+			if watchdogEnabled {
+				states = append(states, "WATCHDOG=1")
+			}
+			if err := notifier.Notify(states...); err != nil {
+				slog.Warn("Failed to notify systemd", "error", err)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if *watchInterval > 0 {
+		d.watcher = infrastructure.NewFileWatcher(*watchInterval, config.watchPaths...)
+		go d.watcher.Watch(ctx, func(changedPaths []string) {
+			slog.Info("Detected config changes", "paths", changedPaths)
+			d.reload(s, clock)
+		})
+	}
+	go handleShutdown(cancel)
+	go d.handleReloadSignal(ctx, s, clock)
+	if history, ok := repository.(ports.PostHistory); ok && retention > 0 {
+		go pruneHistory(ctx, history, retention)
+	}
+	if *adminAddr != "" {
+		if err := startAdminServer(ctx, *adminAddr, *adminTokenFile, s, repository, *healthMaxStall, *healthMaxFailures); err != nil {
+			return d.failStart(err)
+		}
+	}
+	if *metricsAddr != "" {
+		exporter := metrics.NewExporter(d.metrics, s, repository, clock)
+		health := adminapi.NewServer(s, repository, "")
+		health.SetHealthLimits(*healthMaxStall, *healthMaxFailures)
+		exporter.SetHealthHandler(health.HealthHandler())
+		if err := startMetricsServer(ctx, *metricsAddr, exporter); err != nil {
+			return d.failStart(err)
+		}
+	}
+
+	slog.Info("Scheduler started", "jobs", len(config.jobs))
+	if err := notifier.Notify("READY=1", "STATUS="+describeNextPost(s, clock.Now())); err != nil {
+		slog.Warn("Failed to notify systemd", "error", err)
+	}
+	s.Run(ctx, clock)
+	notifier.Notify("STOPPING=1")
+	if flusher, ok := repository.(ports.PostRecordFlusher); ok {
+		if err := flusher.Flush(); err != nil {
+			slog.Error("Failed to flush post records", "error", err)
+		}
+	}
+	slog.Info("Scheduler stopped")
+	return 0
+}
+
+func (d *daemon) failStart(err error) int {
+	slog.Error("Failed to start", "error", err)
+	if !d.logsToStderr {
+		printError("Failed to start: %v", err)
+	}
+	return 1
+}
+
+func flagPassed(flags *flag.FlagSet, name string) bool {
+	passed := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			passed = true
+		}
+	})
+	return passed
+}
+
+func parseByteSize(value string) (int64, error) {
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+	upper := strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range units {
+		if number, found := strings.CutSuffix(upper, unit.suffix); found {
+			upper, multiplier = strings.TrimSpace(number), unit.multiplier
+			break
+		}
+	}
+	size, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || size < 0 || size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid size: %s", value)
+	}
+	return size * multiplier, nil
+}
+
+func startAdminServer(ctx context.Context, address, tokenFile string, s *scheduler.Scheduler, repository ports.PostRecordRepository, maxStall time.Duration, maxFailures int) error {
+	token := os.Getenv("HIJIKI_ADMIN_TOKEN")
+	if tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read admin token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	listener, err := adminapi.Listen(address, token)
+	if err != nil {
+		return err
+	}
+	server := adminapi.NewServer(s, repository, token)
+	server.SetHealthLimits(maxStall, maxFailures)
+	go func() {
+		if err := server.Serve(ctx, listener); err != nil {
+			slog.Error("Admin API stopped", "error", err)
+		}
+	}()
+	slog.Info("Admin API listening", "address", address)
+	return nil
+}
+
+func describeNextPost(s *scheduler.Scheduler, now time.Time) string {
+	upcoming := scheduler.Upcoming(s.Jobs(), now, 1)
+	if len(upcoming) == 0 {
+		return "No scheduled posts"
+	}
+	return fmt.Sprintf("Next: %s at %s", upcoming[0].Job.ID, upcoming[0].FireTime.Format("2006-01-02 15:04"))
+}
+
+func startMetricsServer(ctx context.Context, address string, exporter *metrics.Exporter) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := exporter.Serve(ctx, listener); err != nil {
+			slog.Error("Metrics server stopped", "error", err)
+		}
+	}()
+	slog.Info("Serving metrics", "address", address)
+	return nil
+}
+
+type daemonConfig struct {
+	jobs        []scheduler.Job
+	accounts    []infrastructure.AccountConfig
+	poster      ports.Poster
+	alertPoster ports.Poster
+	muteWindows []domain.MuteWindow
+	alerts      usecases.FailureAlertConfig
+	watchPaths  []string
+}
+
+func (d *daemon) load(clock ports.Clock) (daemonConfig, error) {
+	loaded, err := newScheduleConfigLoader(d.paths).LoadAll()
+	if err != nil {
+		return daemonConfig{}, fmt.Errorf("failed to load schedule config: %w", err)
+	}
+	logConfigWarnings(loaded.Warnings)
+
+	var poster ports.Poster
+	if d.dryRun {
+		poster = createDryRunPoster(loaded.Accounts)
+	} else if poster, err = createPoster(d.paths.envPath, loaded.Accounts); err != nil {
+		return daemonConfig{}, err
+	}
+	alertPoster := poster
+	if d.metrics != nil {
+		poster = metrics.NewPoster(poster, d.metrics, clock)
+	}
+
+	config := daemonConfig{
+		jobs:        createJobsFromScheduleConfigs(loaded.Schedules),
+		accounts:    loaded.Accounts,
+		poster:      poster,
+		alertPoster: alertPoster,
+		muteWindows: loaded.MuteWindows,
+		watchPaths:  append(loaded.WatchPaths, d.paths.envPath),
+	}
+	if loaded.AlertsEnabled {
+		config.alerts = usecases.FailureAlertConfig{
+			Account:     loaded.Alerts.Account,
+			To:          loaded.Alerts.To,
+			Visibility:  loaded.Alerts.Visibility,
+			Threshold:   loaded.Alerts.Threshold,
+			RepeatAfter: loaded.Alerts.RepeatAfter,
+		}
+	}
+	return config, nil
+}
+
+func (d *daemon) verifyAccounts(config daemonConfig) error {
+	checks, err := checkMisskeyAccounts(context.Background(), d.paths.envPath, config.accounts, config.jobs)
+	if err != nil {
+		return err
+	}
+
+	var fatal []error
+	for _, check := range checks {
+		switch {
+		case check.fatal():
+			fatal = append(fatal, fmt.Errorf("account %s", check))
+			for _, problem := range check.problems {
+				fatal = append(fatal, errors.New(problem))
+			}
+		case check.err != nil:
+			slog.Warn("Could not verify account, continuing", "account", check.name, "host", check.host, "error", check.err)
+		default:
+			slog.Info("Verified account", "account", check.name, "host", check.host, "username", check.account.Username, "max_note_text_length", check.account.MaxNoteTextLength)
+		}
+	}
+	if len(fatal) > 0 {
+		return fmt.Errorf("account check failed (run 'hijiki doctor' for details): %w", errors.Join(fatal...))
+	}
+	return nil
+}
+
+func (d *daemon) reload(s *scheduler.Scheduler, clock ports.Clock) {
+	config, err := d.load(clock)
+	if err != nil {
+		slog.Error("Reload failed, keeping the current config", "error", err)
+		return
+	}
+
+	s.SetMuteWindows(config.muteWindows)
+	d.alerts.SetConfig(config.alerts)
+	d.alerts.SetPoster(config.alertPoster)
+	diff := s.Reload(config.jobs, config.poster)
+	slog.Info("Config reloaded", "jobs", len(config.jobs), "changes", diff.String())
+	if d.watcher != nil {
+		d.watcher.SetPaths(config.watchPaths...)
+	}
+}
+
+func (d *daemon) handleReloadSignal(ctx context.Context, s *scheduler.Scheduler, clock ports.Clock) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigChan:
+			slog.Info("Reload signal received")
+			d.reload(s, clock)
+		}
+	}
+}
+
+func pruneHistory(ctx context.Context, history ports.PostHistory, retention time.Duration) {
+	for {
+		pruned, err := history.Prune(time.Now().Add(-retention))
+		if err != nil {
+			slog.Error("Failed to prune post history", "error", err)
+		} else if pruned > 0 {
+			slog.Info("Pruned post history", "entries", pruned, "retention", retention)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(24 * time.Hour):
+		}
+	}
+}
+
+func handleShutdown(cancel context.CancelFunc) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+	slog.Info("Shutdown signal received, finishing in-flight posts (send again to exit immediately)")
+	cancel()
+	<-sigChan
+	slog.Warn("Second shutdown signal received, exiting without waiting for in-flight posts")
+	os.Exit(1)
+}
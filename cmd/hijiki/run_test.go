@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+		wantErr  bool
+	}{
+		{value: "0", expected: 0},
+		{value: "512", expected: 512},
+		{value: "512B", expected: 512},
+		{value: "10KB", expected: 10 << 10},
+		{value: "10mb", expected: 10 << 20},
+		{value: " 2 GB ", expected: 2 << 30},
+		{value: "", wantErr: true},
+		{value: "MB", wantErr: true},
+		{value: "-1MB", wantErr: true},
+		{value: "1.5MB", wantErr: true},
+		{value: "10TB", wantErr: true},
+		{value: "ten", wantErr: true},
+		{value: "9999999999999GB", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			size, err := parseByteSize(test.value)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, size)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+const simulationDateLayout = "2006-01-02"
+
+func runSimulate(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	registerPauseFlag(flags, &paths)
+	recordsPath := flags.String("records", "", "post records file used as the starting state (default: no records)")
+	fromText := flags.String("from", "", "first day to simulate (YYYY-MM-DD, required)")
+	toText := flags.String("to", "", "last day to simulate (YYYY-MM-DD, inclusive, required)")
+	timezone := flags.String("tz", "Local", "time zone used for schedules")
+	format := flags.String("format", "table", "output format: table or json")
+	holidaysPath := flags.String("holidays", "", "file listing holidays to mark, one \"YYYY-MM-DD [name]\" per line")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	location, err := time.LoadLocation(*timezone)
+	if err != nil {
+		printError("Invalid time zone: %v", err)
+		return 2
+	}
+	from, err := time.ParseInLocation(simulationDateLayout, *fromText, location)
+	if err != nil {
+		printError("Invalid --from date: %v", err)
+		return 2
+	}
+	to, err := time.ParseInLocation(simulationDateLayout, *toText, location)
+	if err != nil {
+		printError("Invalid --to date: %v", err)
+		return 2
+	}
+	if to.Before(from) {
+		printError("--to must not be before --from")
+		return 2
+	}
+
+	jobs, _, err := loadSchedulerConfig(paths)
+	if err != nil {
+		printError("%v", err)
+		return 1
+	}
+
+	muteWindows, err := newScheduleConfigLoader(paths).LoadMuteWindows()
+	if err != nil {
+		printError("Failed to load mute windows: %v", err)
+		return 1
+	}
+	pauses, err := infrastructure.NewJSONPauseStore(paths.pausePath).Load()
+	if err != nil {
+		printError("Failed to read pause state: %v", err)
+		return 1
+	}
+
+	var holidays map[string]string
+	if *holidaysPath != "" {
+		holidays, err = loadHolidays(*holidaysPath)
+		if err != nil {
+			printError("Failed to load holidays: %v", err)
+			return 1
+		}
+	}
+
+	var baseRepository ports.PostRecordRepository = infrastructure.EmptyPostRecordRepository{}
+	if *recordsPath != "" {
+		baseRepository, err = openReadOnlyPostRecordRepository(*recordsPath)
+		if err != nil {
+			printError("%v", err)
+			return 1
+		}
+		defer closePostRecordRepository(baseRepository)
+	}
+	repository := infrastructure.NewInMemoryPostRecordRepository(baseRepository)
+
+	events := scheduler.Simulate(repository, jobs, from, to.AddDate(0, 0, 1), scheduler.SimulationOptions{
+		MuteWindows: muteWindows,
+		Pauses:      pauses,
+		Holidays:    holidays,
+	})
+
+	switch *format {
+	case "table":
+		err = writeSimulationTable(os.Stdout, events)
+	case "json":
+		err = writeJSON(os.Stdout, events)
+	default:
+		printError("Unknown format: %s", *format)
+		return 2
+	}
+	if err != nil {
+		printError("Failed to write output: %v", err)
+		return 1
+	}
+	return 0
+}
+
+func writeSimulationTable(output io.Writer, events []scheduler.SimulationEvent) error {
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SCHEDULED AT\tSCHEDULE\tACCOUNT\tSTATUS\tCONTENT\tNOTE")
+	for _, event := range events {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			event.ScheduledAt.Format(time.RFC3339),
+			event.ScheduleID,
+			defaultAccountName(event.Post.Account),
+			event.Status,
+			summarizeText(event.Post.Text, 40),
+			simulationNote(event),
+		)
+	}
+	return writer.Flush()
+}
+
+func simulationNote(event scheduler.SimulationEvent) string {
+	note := strings.TrimSpace(event.Reason + " " + event.Note)
+	switch {
+	case !event.Holiday:
+		return note
+	case event.HolidayName == "":
+		return strings.TrimSpace("holiday " + note)
+	default:
+		return strings.TrimSpace("holiday (" + event.HolidayName + ") " + note)
+	}
+}
+
+func loadHolidays(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseHolidays(file)
+}
+
+func parseHolidays(input io.Reader) (map[string]string, error) {
+	holidays := make(map[string]string)
+	scanner := bufio.NewScanner(input)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dateText := strings.Fields(line)[0]
+		name := strings.TrimSpace(strings.TrimPrefix(line, dateText))
+		date, err := time.Parse(scheduler.HolidayDateLayout, dateText)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", lineNumber, dateText)
+		}
+		holidays[date.Format(scheduler.HolidayDateLayout)] = name
+	}
+	return holidays, scanner.Err()
+}
+
+func writeJSON(output io.Writer, value any) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func defaultAccountName(account string) string {
+	if account == "" {
+		return infrastructure.DefaultAccountName
+	}
+	return account
+}
+
+func summarizeText(text string, maxRunes int) string {
+	singleLine := strings.Join(strings.Fields(text), " ")
+	runes := []rune(singleLine)
+	if len(runes) <= maxRunes {
+		return singleLine
+	}
+	return string(runes[:maxRunes-1]) + "…"
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHolidays(t *testing.T) {
+	holidays, err := parseHolidays(strings.NewReader("# 2027年\n2027-01-01 元日\n\n2027-01-11\t成人の日\n2027-05-06\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"2027-01-01": "元日", "2027-01-11": "成人の日", "2027-05-06": ""}, holidays)
+}
+
+func TestParseHolidays_InvalidDate_ReturnsLineNumber(t *testing.T) {
+	_, err := parseHolidays(strings.NewReader("2027-01-01 元日\n2027/01/11 成人の日\n"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+)
+
+func runValidate(args []string) int {
+	var paths pathFlags
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	registerConfigFlag(flags, &paths)
+	registerEnvFlag(flags, &paths)
+	skipEnv := flags.Bool("skip-env", false, "do not check the env file")
+	strict := flags.Bool("strict", false, "treat warnings as errors")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	issues, err := newScheduleConfigLoader(paths).Validate()
+	if err != nil {
+		printError("%s: %v", paths.configPath, err)
+		return 1
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, issue := range issues {
+		printError("%s", issue)
+		if issue.Severity == infrastructure.SeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+
+	if !*skipEnv {
+		if _, err := infrastructure.NewEnvConfigLoader(paths.envPath).Load(); err != nil {
+			printError("%s: error: %v", paths.envPath, err)
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 || (*strict && warningCount > 0) {
+		printError("%d error(s), %d warning(s)", errorCount, warningCount)
+		return 1
+	}
+
+	fmt.Fprintf(os.Stdout, "%s: OK (%d warning(s))\n", paths.configPath, warningCount)
+	return 0
+}
+
+func logConfigWarnings(issues []infrastructure.ValidationIssue) {
+	for _, issue := range issues {
+		if issue.Severity == infrastructure.SeverityWarning {
+			slog.Warn("Config warning", "file", issue.File, "path", issue.Path, "schedule_id", issue.ID, "message", issue.Message)
+		}
+	}
+}
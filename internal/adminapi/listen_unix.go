@@ -0,0 +1,21 @@
+//go:build unix
+
+package adminapi
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+func listenUnix(path string) (net.Listener, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict permissions of %s: %w", path, err)
+	}
+	return listener, nil
+}
@@ -0,0 +1,398 @@
+package adminapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+const (
+	unixAddressPrefix  = "unix:"
+	DefaultMaxStall    = 3 * time.Minute
+	DefaultMaxFailures = 3
+)
+
+type Server struct {
+	scheduler   *scheduler.Scheduler
+	repository  ports.PostRecordRepository
+	token       string
+	mux         *http.ServeMux
+	maxStall    time.Duration
+	maxFailures int
+}
+
+type scheduleResponse struct {
+	ID       string    `json:"id"`
+	Schedule string    `json:"schedule"`
+	Account  string    `json:"account"`
+	Source   string    `json:"source"`
+	Paused   bool      `json:"paused"`
+	NextTime time.Time `json:"nextTime"`
+}
+
+type attemptResponse struct {
+	ID          string     `json:"attemptId"`
+	ScheduleID  string     `json:"id"`
+	Force       bool       `json:"force"`
+	Status      string     `json:"status"`
+	NoteID      string     `json:"noteId,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	RequestedAt time.Time  `json:"requestedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+type historyResponse struct {
+	ScheduleID  string    `json:"scheduleId"`
+	Account     string    `json:"account"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	Status      string    `json:"status"`
+	LatencyMS   int64     `json:"latencyMs"`
+	TextHash    string    `json:"textHash"`
+	NoteID      string    `json:"noteId"`
+	Error       string    `json:"error"`
+}
+
+type pauseResponse struct {
+	All       bool     `json:"all"`
+	Schedules []string `json:"schedules"`
+}
+
+type healthResponse struct {
+	Status   string   `json:"status"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewServer(s *scheduler.Scheduler, repository ports.PostRecordRepository, token string) *Server {
+	server := &Server{
+		scheduler:   s,
+		repository:  repository,
+		token:       token,
+		mux:         http.NewServeMux(),
+		maxStall:    DefaultMaxStall,
+		maxFailures: DefaultMaxFailures,
+	}
+	server.mux.HandleFunc("GET /healthz", server.health)
+	server.mux.HandleFunc("GET /schedules", server.listSchedules)
+	server.mux.HandleFunc("POST /schedules/{id}/pause", server.pauseSchedule)
+	server.mux.HandleFunc("POST /schedules/{id}/resume", server.resumeSchedule)
+	server.mux.HandleFunc("POST /schedules/{id}/trigger", server.triggerSchedule)
+	server.mux.HandleFunc("GET /attempts/{id}", server.getAttempt)
+	server.mux.HandleFunc("POST /pause", server.pauseAll)
+	server.mux.HandleFunc("POST /resume", server.resumeAll)
+	server.mux.HandleFunc("GET /history", server.listHistory)
+	return server
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" && r.URL.Path != "/healthz" && !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) SetHealthLimits(maxStall time.Duration, maxFailures int) {
+	s.maxStall = maxStall
+	s.maxFailures = maxFailures
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func Listen(address, token string) (net.Listener, error) {
+	if token == "" {
+		return nil, fmt.Errorf("an admin token is required when listening on %s", address)
+	}
+	if path, isUnix := strings.CutPrefix(address, unixAddressPrefix); isUnix {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+		return listenUnix(path)
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin address %q: %w", address, err)
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("admin address %q must be a loopback address or a unix socket", address)
+	}
+	return net.Listen("tcp", address)
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) HealthHandler() http.Handler {
+	return http.HandlerFunc(s.health)
+}
+
+func (s *Server) health(w http.ResponseWriter, r *http.Request) {
+	var problems []string
+	if age, started := s.scheduler.HeartbeatAge(); !started {
+		problems = append(problems, "scheduler loop has not started")
+	} else if age > s.maxStall {
+		problems = append(problems, fmt.Sprintf("scheduler loop stalled for %s", age.Round(time.Second)))
+	}
+	if s.maxFailures > 0 && s.recentPostsFailed() {
+		problems = append(problems, fmt.Sprintf("last %d post(s) failed", s.maxFailures))
+	}
+
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusServiceUnavailable, healthResponse{Status: "unhealthy", Problems: problems})
+		return
+	}
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+func (s *Server) recentPostsFailed() bool {
+	history, ok := s.repository.(ports.PostHistory)
+	if !ok {
+		return false
+	}
+	records, err := history.Query(ports.PostHistoryQuery{Limit: s.maxFailures})
+	if err != nil || len(records) < s.maxFailures {
+		return false
+	}
+	for _, record := range records {
+		if !record.Failed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) listSchedules(w http.ResponseWriter, r *http.Request) {
+	states := s.scheduler.States()
+	schedules := make([]scheduleResponse, 0, len(states))
+	for _, state := range states {
+		schedules = append(schedules, newScheduleResponse(state))
+	}
+	writeJSON(w, http.StatusOK, schedules)
+}
+
+func (s *Server) pauseSchedule(w http.ResponseWriter, r *http.Request) {
+	s.setPaused(w, r.PathValue("id"), s.scheduler.Pause)
+}
+
+func (s *Server) resumeSchedule(w http.ResponseWriter, r *http.Request) {
+	s.setPaused(w, r.PathValue("id"), s.scheduler.Resume)
+}
+
+func (s *Server) setPaused(w http.ResponseWriter, id string, apply func(string) error) {
+	if err := apply(id); err != nil {
+		writeSchedulerError(w, err)
+		return
+	}
+	for _, state := range s.scheduler.States() {
+		if state.Job.ID == id {
+			writeJSON(w, http.StatusOK, newScheduleResponse(state))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "unknown schedule: "+id)
+}
+
+func (s *Server) pauseAll(w http.ResponseWriter, r *http.Request) {
+	s.setAllPaused(w, s.scheduler.PauseAll)
+}
+
+func (s *Server) resumeAll(w http.ResponseWriter, r *http.Request) {
+	s.setAllPaused(w, s.scheduler.ResumeAll)
+}
+
+func (s *Server) setAllPaused(w http.ResponseWriter, apply func() error) {
+	if err := apply(); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	state, err := s.scheduler.PauseState()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, pauseResponse{All: state.All, Schedules: state.PausedSchedules()})
+}
+
+func (s *Server) triggerSchedule(w http.ResponseWriter, r *http.Request) {
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+
+	attempt, err := s.scheduler.Dispatch(r.PathValue("id"), force)
+	if err != nil {
+		writeSchedulerError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/attempts/"+attempt.ID)
+	writeJSON(w, http.StatusAccepted, newAttemptResponse(attempt))
+}
+
+func (s *Server) getAttempt(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	attempt, exists := s.scheduler.FindAttempt(id)
+	if !exists {
+		writeError(w, http.StatusNotFound, "unknown attempt: "+id)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAttemptResponse(attempt))
+}
+
+func (s *Server) listHistory(w http.ResponseWriter, r *http.Request) {
+	history, ok := s.repository.(ports.PostHistory)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "the post record repository does not keep history")
+		return
+	}
+
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	records, err := history.Query(query)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	entries := make([]historyResponse, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, historyResponse{
+			ScheduleID:  record.ScheduleID,
+			Account:     defaultString(record.Account, infrastructure.DefaultAccountName),
+			AttemptedAt: record.LastPostedAt,
+			Status:      string(record.Status),
+			LatencyMS:   record.Latency.Milliseconds(),
+			TextHash:    record.TextHash,
+			NoteID:      record.NoteID,
+			Error:       record.Error,
+		})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func parseHistoryQuery(r *http.Request) (ports.PostHistoryQuery, error) {
+	values := r.URL.Query()
+	query := ports.PostHistoryQuery{ScheduleID: values.Get("id"), Status: domain.PostStatus(values.Get("status")), Limit: 50}
+
+	switch query.Status {
+	case "", domain.PostStatusPosted, domain.PostStatusFailed:
+	default:
+		return query, fmt.Errorf("invalid status: %s (expected posted or failed)", query.Status)
+	}
+	if since := values.Get("since"); since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return query, fmt.Errorf("invalid since: %s (expected RFC 3339)", since)
+		}
+		query.Since = sinceTime
+	}
+	if limit := values.Get("limit"); limit != "" {
+		count, err := strconv.Atoi(limit)
+		if err != nil || count < 0 {
+			return query, fmt.Errorf("invalid limit: %s", limit)
+		}
+		query.Limit = count
+	}
+	return query, nil
+}
+
+func newScheduleResponse(state scheduler.JobState) scheduleResponse {
+	return scheduleResponse{
+		ID:       state.Job.ID,
+		Schedule: state.Job.Schedule.String(),
+		Account:  defaultString(state.Job.Account, infrastructure.DefaultAccountName),
+		Source:   state.Job.Source,
+		Paused:   state.Paused,
+		NextTime: state.NextTime,
+	}
+}
+
+func newAttemptResponse(attempt scheduler.Attempt) attemptResponse {
+	response := attemptResponse{
+		ID:          attempt.ID,
+		ScheduleID:  attempt.ScheduleID,
+		Force:       attempt.Force,
+		Status:      string(attempt.Status),
+		NoteID:      attempt.NoteID,
+		Error:       attempt.Error,
+		RequestedAt: attempt.RequestedAt,
+	}
+	if !attempt.FinishedAt.IsZero() {
+		response.FinishedAt = &attempt.FinishedAt
+	}
+	return response
+}
+
+func writeSchedulerError(w http.ResponseWriter, err error) {
+	if errors.Is(err, scheduler.ErrUnknownSchedule) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, usecases.ErrPaused) || errors.Is(err, usecases.ErrMuted) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, scheduler.ErrNotRunning) {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	writeError(w, http.StatusBadGateway, err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,276 @@
+package adminapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/adminapi"
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FakeClock struct {
+	currentTime time.Time
+	mutex       sync.Mutex
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.currentTime
+}
+
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.currentTime = c.currentTime.Add(d)
+}
+
+type FakePoster struct {
+	postCount int
+	mutex     sync.Mutex
+}
+
+func (p *FakePoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.postCount++
+	return domain.PostResult{ID: "note-1"}, nil
+}
+
+func (p *FakePoster) Count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.postCount
+}
+
+func newTestServer(t *testing.T, token string) (*adminapi.Server, *scheduler.Scheduler, *FakePoster, ports.PostRecordRepository) {
+	server, s, poster, repository, _ := newTestServerWithClock(t, token)
+	return server, s, poster, repository
+}
+
+func newTestServerWithClock(t *testing.T, token string) (*adminapi.Server, *scheduler.Scheduler, *FakePoster, ports.PostRecordRepository, *FakeClock) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	repository := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	poster := &FakePoster{}
+	s := scheduler.New(clock, repository, poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+		{ID: "evening", Schedule: domain.NewDailySchedule(18, 0), Content: "Evening", Account: "mastodon"},
+	})
+	return adminapi.NewServer(s, repository, token), s, poster, repository, clock
+}
+
+func runScheduler(t *testing.T, s *scheduler.Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, infrastructure.NewRealClock())
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool {
+		_, started := s.HeartbeatAge()
+		return started
+	}, time.Second, time.Millisecond)
+}
+
+func waitForAttempt(t *testing.T, server http.Handler, attemptID string) map[string]any {
+	t.Helper()
+	var attempt map[string]any
+	require.Eventually(t, func() bool {
+		response := serve(server, "GET", "/attempts/"+attemptID, "secret")
+		require.Equal(t, http.StatusOK, response.Code)
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &attempt))
+		return attempt["status"] != "queued"
+	}, time.Second, time.Millisecond)
+	return attempt
+}
+
+func serve(server http.Handler, method, target, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, nil)
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestServer_RejectsRequestsWithoutToken(t *testing.T) {
+	server, _, _, _ := newTestServer(t, "secret")
+
+	assert.Equal(t, http.StatusUnauthorized, serve(server, "GET", "/schedules", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(server, "GET", "/schedules", "wrong").Code)
+	assert.Equal(t, http.StatusOK, serve(server, "GET", "/schedules", "secret").Code)
+}
+
+func TestServer_ListSchedules_ReturnsNextFireTimes(t *testing.T) {
+	server, _, _, _ := newTestServer(t, "secret")
+
+	response := serve(server, "GET", "/schedules", "secret")
+
+	var schedules []map[string]any
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &schedules))
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "noon", schedules[0]["id"])
+	assert.Equal(t, "default", schedules[0]["account"])
+	assert.Equal(t, "2026-02-02T12:00:00Z", schedules[0]["nextTime"])
+	assert.Equal(t, "mastodon", schedules[1]["account"])
+	assert.Equal(t, "2026-02-01T18:00:00Z", schedules[1]["nextTime"])
+}
+
+func TestServer_PauseAndResume_UpdateSchedulerState(t *testing.T) {
+	server, s, _, _ := newTestServer(t, "secret")
+
+	response := serve(server, "POST", "/schedules/noon/pause", "secret")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"id": "noon", "schedule": "daily 12:00", "account": "default", "source": "", "paused": true, "nextTime": "2026-02-02T12:00:00Z"}`, response.Body.String())
+	assert.True(t, s.IsPaused("noon"))
+
+	assert.Equal(t, http.StatusOK, serve(server, "POST", "/schedules/noon/resume", "secret").Code)
+	assert.False(t, s.IsPaused("noon"))
+}
+
+func TestServer_PauseAll_BlocksTriggerUntilResumed(t *testing.T) {
+	server, s, poster, _ := newTestServer(t, "secret")
+	runScheduler(t, s)
+
+	response := serve(server, "POST", "/pause", "secret")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"all": true, "schedules": []}`, response.Body.String())
+	assert.Equal(t, http.StatusConflict, serve(server, "POST", "/schedules/noon/trigger", "secret").Code)
+	assert.Equal(t, 0, poster.Count())
+
+	assert.Equal(t, http.StatusOK, serve(server, "POST", "/resume", "secret").Code)
+	assert.Equal(t, http.StatusAccepted, serve(server, "POST", "/schedules/noon/trigger", "secret").Code)
+	assert.Eventually(t, func() bool { return poster.Count() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestServer_UnknownSchedule_ReturnsNotFound(t *testing.T) {
+	server, _, _, _ := newTestServer(t, "secret")
+
+	assert.Equal(t, http.StatusNotFound, serve(server, "POST", "/schedules/missing/pause", "secret").Code)
+	assert.Equal(t, http.StatusNotFound, serve(server, "POST", "/schedules/missing/trigger", "secret").Code)
+}
+
+func TestServer_Trigger_QueuesAttemptAndReportsResult(t *testing.T) {
+	server, s, poster, _ := newTestServer(t, "secret")
+	runScheduler(t, s)
+
+	response := serve(server, "POST", "/schedules/noon/trigger", "secret")
+	require.Equal(t, http.StatusAccepted, response.Code)
+	var queued map[string]any
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &queued))
+	attemptID, _ := queued["attemptId"].(string)
+	require.NotEmpty(t, attemptID)
+	assert.Equal(t, "/attempts/"+attemptID, response.Header().Get("Location"))
+	assert.Equal(t, "noon", queued["id"])
+
+	result := waitForAttempt(t, server, attemptID)
+	assert.Equal(t, "posted", result["status"])
+	assert.Equal(t, "note-1", result["noteId"])
+
+	response = serve(server, "POST", "/schedules/noon/trigger", "secret")
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &queued))
+	result = waitForAttempt(t, server, queued["attemptId"].(string))
+	assert.Equal(t, "skipped", result["status"])
+	assert.Equal(t, 1, poster.Count())
+}
+
+func TestServer_Trigger_WhenSchedulerIsNotRunning_ReturnsServiceUnavailable(t *testing.T) {
+	server, _, poster, _ := newTestServer(t, "secret")
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(server, "POST", "/schedules/noon/trigger", "secret").Code)
+	assert.Equal(t, http.StatusNotFound, serve(server, "GET", "/attempts/noon-1", "secret").Code)
+	assert.Equal(t, 0, poster.Count())
+}
+
+func TestServer_History_FiltersByQuery(t *testing.T) {
+	server, _, _, repository := newTestServer(t, "secret")
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repository.Save(domain.NewPostRecord("noon", postedAt)))
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("evening", postedAt.Add(6*time.Hour), assert.AnError)))
+
+	response := serve(server, "GET", "/history?status=failed", "secret")
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "evening", entries[0]["scheduleId"])
+	assert.Equal(t, http.StatusBadRequest, serve(server, "GET", "/history?status=bogus", "secret").Code)
+}
+
+func TestServer_Healthz_ReportsStalledLoopWithoutToken(t *testing.T) {
+	server, s, _, _, clock := newTestServerWithClock(t, "secret")
+	assert.Equal(t, http.StatusServiceUnavailable, serve(server, "GET", "/healthz", "").Code)
+
+	runScheduler(t, s)
+
+	response := serve(server, "GET", "/healthz", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, response.Body.String())
+
+	clock.Advance(4 * time.Minute)
+	response = serve(server, "GET", "/healthz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Contains(t, response.Body.String(), "scheduler loop stalled for 4m0s")
+}
+
+func TestServer_Healthz_FailsAfterConsecutiveFailedPosts(t *testing.T) {
+	server, _, _, repository := newTestServer(t, "secret")
+	server.SetHealthLimits(time.Hour, 2)
+	attemptedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("noon", attemptedAt, assert.AnError)))
+	require.NoError(t, repository.Save(domain.NewPostRecord("noon", attemptedAt.Add(time.Minute))))
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("evening", attemptedAt.Add(2*time.Minute), assert.AnError)))
+
+	response := serve(server, "GET", "/healthz", "")
+	assert.NotContains(t, response.Body.String(), "post(s) failed")
+
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("evening", attemptedAt.Add(3*time.Minute), assert.AnError)))
+	response = serve(server, "GET", "/healthz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, response.Code)
+	assert.Contains(t, response.Body.String(), "last 2 post(s) failed")
+}
+
+func TestListen_RejectsNonLoopbackAddressesAndMissingToken(t *testing.T) {
+	_, err := adminapi.Listen("0.0.0.0:0", "secret")
+	assert.Error(t, err)
+
+	_, err = adminapi.Listen("127.0.0.1:0", "")
+	assert.Error(t, err)
+
+	listener, err := adminapi.Listen("127.0.0.1:0", "secret")
+	require.NoError(t, err)
+	listener.Close()
+}
+
+func TestListen_UnixSocket_RequiresTokenAndIsReadableOnlyByOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin.sock")
+
+	_, err := adminapi.Listen("unix:"+path, "")
+	require.Error(t, err)
+
+	listener, err := adminapi.Listen("unix:"+path, "secret")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
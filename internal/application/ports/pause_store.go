@@ -0,0 +1,8 @@
+package ports
+
+import "github.com/CAT5NEKO/hijikiTool/internal/domain"
+
+type PauseStore interface {
+	Load() (domain.PauseState, error)
+	Save(state domain.PauseState) error
+}
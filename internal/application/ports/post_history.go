@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type PostHistoryQuery struct {
+	ScheduleID string
+	Since      time.Time
+	Status     domain.PostStatus
+	Limit      int
+}
+
+type PostHistory interface {
+	Append(record domain.PostRecord) error
+	Query(query PostHistoryQuery) ([]domain.PostRecord, error)
+	Prune(before time.Time) (int, error)
+}
@@ -6,3 +6,7 @@ type PostRecordRepository interface {
 	Find(scheduleID string) (domain.PostRecord, error)
 	Save(record domain.PostRecord) error
 }
+
+type PostRecordFlusher interface {
+	Flush() error
+}
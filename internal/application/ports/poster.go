@@ -1,5 +1,11 @@
 package ports
 
+import (
+	"context"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
 type Poster interface {
-	Post(content string) error
+	Post(ctx context.Context, post domain.Post) (domain.PostResult, error)
 }
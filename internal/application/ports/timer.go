@@ -0,0 +1,12 @@
+package ports
+
+import "time"
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type TimerSource interface {
+	NewTimer(d time.Duration) Timer
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type FailureAlertConfig struct {
+	Account     string
+	To          string
+	Visibility  string
+	Threshold   int
+	RepeatAfter time.Duration
+}
+
+type FailureAlertUseCase struct {
+	clock    ports.Clock
+	poster   ports.Poster
+	config   FailureAlertConfig
+	failures map[string]int
+	alerts   map[string]time.Time
+	mutex    sync.Mutex
+}
+
+func NewFailureAlertUseCase(clock ports.Clock) *FailureAlertUseCase {
+	return &FailureAlertUseCase{clock: clock, failures: make(map[string]int), alerts: make(map[string]time.Time)}
+}
+
+func (u *FailureAlertUseCase) SetConfig(config FailureAlertConfig) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.config = config
+}
+
+func (u *FailureAlertUseCase) SetPoster(poster ports.Poster) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.poster = poster
+}
+
+func (u *FailureAlertUseCase) RecordFailure(ctx context.Context, account, scheduleID string, cause error) error {
+	u.mutex.Lock()
+	config, poster := u.config, u.poster
+	now := u.clock.Now()
+	u.failures[account]++
+	failures := u.failures[account]
+	lastAlert, alerted := u.alerts[account]
+	u.mutex.Unlock()
+
+	if config.Threshold <= 0 || failures < config.Threshold {
+		return nil
+	}
+	if alerted && (config.RepeatAfter <= 0 || now.Sub(lastAlert) < config.RepeatAfter) {
+		return nil
+	}
+
+	text := fmt.Sprintf("hijiki: posts to account %s failed %d times in a row (latest: %s): %v", accountName(account), failures, scheduleID, cause)
+	if err := u.send(ctx, poster, config, text); err != nil {
+		return err
+	}
+
+	u.mutex.Lock()
+	u.alerts[account] = now
+	u.mutex.Unlock()
+	return nil
+}
+
+func (u *FailureAlertUseCase) RecordSuccess(ctx context.Context, account string) error {
+	u.mutex.Lock()
+	config, poster := u.config, u.poster
+	_, alerted := u.alerts[account]
+	delete(u.alerts, account)
+	delete(u.failures, account)
+	u.mutex.Unlock()
+
+	if !alerted || config.Threshold <= 0 {
+		return nil
+	}
+	return u.send(ctx, poster, config, fmt.Sprintf("hijiki: posts to account %s are working again", accountName(account)))
+}
+
+func (u *FailureAlertUseCase) send(ctx context.Context, poster ports.Poster, config FailureAlertConfig, text string) error {
+	if poster == nil {
+		return errors.New("failed to send alert: no poster configured")
+	}
+	if config.To != "" {
+		text = config.To + " " + text
+	}
+	_, err := poster.Post(ctx, domain.Post{Account: config.Account, Text: text, Visibility: config.Visibility})
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	return nil
+}
+
+func accountName(account string) string {
+	if account == "" {
+		return "default"
+	}
+	return account
+}
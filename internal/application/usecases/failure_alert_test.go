@@ -0,0 +1,110 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type RecordingPoster struct {
+	posts     []domain.Post
+	postError error
+}
+
+func (p *RecordingPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	if p.postError != nil {
+		return domain.PostResult{}, p.postError
+	}
+	p.posts = append(p.posts, post)
+	return domain.PostResult{}, nil
+}
+
+func newFailureAlertUseCase(clock *FakeClock, poster *RecordingPoster) *usecases.FailureAlertUseCase {
+	useCase := usecases.NewFailureAlertUseCase(clock)
+	useCase.SetConfig(usecases.FailureAlertConfig{Account: "ops", To: "@admin", Visibility: "specified", Threshold: 3, RepeatAfter: 6 * time.Hour})
+	useCase.SetPoster(poster)
+	return useCase
+}
+
+func TestFailureAlertUseCase_AlertsOnceThresholdIsReached(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &RecordingPoster{}
+	useCase := newFailureAlertUseCase(clock, poster)
+	cause := errors.New("request failed with status code: 401")
+
+	for _, scheduleID := range []string{"morning", "noon", "evening", "night"} {
+		require.NoError(t, useCase.RecordFailure(context.Background(), "", scheduleID, cause))
+	}
+
+	require.Len(t, poster.posts, 1)
+	assert.Equal(t, "ops", poster.posts[0].Account)
+	assert.Equal(t, "specified", poster.posts[0].Visibility)
+	assert.Equal(t, "@admin hijiki: posts to account default failed 3 times in a row (latest: evening): request failed with status code: 401", poster.posts[0].Text)
+}
+
+func TestFailureAlertUseCase_CountsFailuresPerAccount(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &RecordingPoster{}
+	useCase := newFailureAlertUseCase(clock, poster)
+
+	require.NoError(t, useCase.RecordFailure(context.Background(), "misskey", "a", assert.AnError))
+	require.NoError(t, useCase.RecordFailure(context.Background(), "misskey", "b", assert.AnError))
+	require.NoError(t, useCase.RecordSuccess(context.Background(), "mastodon"))
+	require.NoError(t, useCase.RecordFailure(context.Background(), "mastodon", "c", assert.AnError))
+	require.NoError(t, useCase.RecordFailure(context.Background(), "misskey", "d", assert.AnError))
+
+	require.Len(t, poster.posts, 1)
+	assert.Contains(t, poster.posts[0].Text, "account misskey failed 3 times")
+}
+
+func TestFailureAlertUseCase_RepeatsAfterIntervalAndSendsRecovery(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &RecordingPoster{}
+	useCase := newFailureAlertUseCase(clock, poster)
+
+	for range 3 {
+		require.NoError(t, useCase.RecordFailure(context.Background(), "ops", "noon", assert.AnError))
+	}
+	clock.fixedTime = clock.fixedTime.Add(6 * time.Hour)
+	require.NoError(t, useCase.RecordFailure(context.Background(), "ops", "noon", assert.AnError))
+	require.NoError(t, useCase.RecordSuccess(context.Background(), "ops"))
+	require.NoError(t, useCase.RecordSuccess(context.Background(), "ops"))
+
+	require.Len(t, poster.posts, 3)
+	assert.Contains(t, poster.posts[1].Text, "failed 4 times in a row")
+	assert.Equal(t, "@admin hijiki: posts to account ops are working again", poster.posts[2].Text)
+}
+
+func TestFailureAlertUseCase_RetriesAlertWhenSendingFails(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &RecordingPoster{postError: errors.New("instance down")}
+	useCase := newFailureAlertUseCase(clock, poster)
+
+	require.NoError(t, useCase.RecordFailure(context.Background(), "", "noon", assert.AnError))
+	require.NoError(t, useCase.RecordFailure(context.Background(), "", "noon", assert.AnError))
+	assert.Error(t, useCase.RecordFailure(context.Background(), "", "noon", assert.AnError))
+	poster.postError = nil
+	require.NoError(t, useCase.RecordFailure(context.Background(), "", "noon", assert.AnError))
+
+	assert.Len(t, poster.posts, 1)
+}
+
+func TestFailureAlertUseCase_WithoutConfig_DoesNothing(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &RecordingPoster{}
+	useCase := usecases.NewFailureAlertUseCase(clock)
+	useCase.SetPoster(poster)
+
+	for range 10 {
+		require.NoError(t, useCase.RecordFailure(context.Background(), "", "noon", assert.AnError))
+	}
+	require.NoError(t, useCase.RecordSuccess(context.Background(), ""))
+
+	assert.Empty(t, poster.posts)
+}
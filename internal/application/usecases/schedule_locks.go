@@ -0,0 +1,25 @@
+package usecases
+
+import "sync"
+
+type ScheduleLocks struct {
+	mutex sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func NewScheduleLocks() *ScheduleLocks {
+	return &ScheduleLocks{locks: make(map[string]*sync.Mutex)}
+}
+
+func (l *ScheduleLocks) lock(scheduleID string) func() {
+	l.mutex.Lock()
+	lock, exists := l.locks[scheduleID]
+	if !exists {
+		lock = &sync.Mutex{}
+		l.locks[scheduleID] = lock
+	}
+	l.mutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
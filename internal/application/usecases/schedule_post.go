@@ -1,17 +1,41 @@
 package usecases
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
 	"github.com/CAT5NEKO/hijikiTool/internal/domain"
 )
 
+var (
+	ErrPaused = errors.New("posting is paused")
+	ErrMuted  = errors.New("posting is muted")
+)
+
+type MutedError struct {
+	Window domain.MuteWindow
+	Until  time.Time
+}
+
+func (e *MutedError) Error() string {
+	return fmt.Sprintf("posting is muted during %s (policy: %s)", e.Window, e.Window.Policy)
+}
+
+func (e *MutedError) Is(target error) bool {
+	return target == ErrMuted
+}
+
 type SchedulePostUseCase struct {
 	clock      ports.Clock
 	repository ports.PostRecordRepository
 	poster     ports.Poster
 	guard      *domain.PostGuard
+	locks      *ScheduleLocks
+	pauses     ports.PauseStore
+	mutes      []domain.MuteWindow
 }
 
 func NewSchedulePostUseCase(
@@ -25,26 +49,122 @@ func NewSchedulePostUseCase(
 		repository: repository,
 		poster:     poster,
 		guard:      guard,
+		locks:      NewScheduleLocks(),
 	}
 }
 
-func (u *SchedulePostUseCase) Execute(scheduleID string, schedule domain.Schedule, content string) error {
+func (u *SchedulePostUseCase) SetPauseStore(pauses ports.PauseStore) {
+	u.pauses = pauses
+}
+
+func (u *SchedulePostUseCase) SetScheduleLocks(locks *ScheduleLocks) {
+	u.locks = locks
+}
+
+func (u *SchedulePostUseCase) SetMuteWindows(windows []domain.MuteWindow) {
+	u.mutes = windows
+}
+
+func (u *SchedulePostUseCase) Execute(ctx context.Context, scheduleID string, schedule domain.Schedule, post domain.Post) (domain.PostRecord, error) {
+	defer u.locks.lock(scheduleID)()
+
 	now := u.clock.Now()
 	record, err := u.repository.Find(scheduleID)
 	if err != nil {
-		return err
+		return domain.PostRecord{}, err
 	}
 
 	if !u.guard.CanPost(schedule, record, now) {
-		return nil
+		return domain.PostRecord{}, nil
+	}
+	if err := u.checkSilenced(scheduleID, now); err != nil {
+		return domain.PostRecord{}, err
 	}
 
-	if err := u.poster.Post(content); err != nil {
-		return err
+	return u.post(ctx, scheduleID, schedule, post, now, time.Time{})
+}
+
+func (u *SchedulePostUseCase) ExecuteDeferred(ctx context.Context, scheduleID string, schedule domain.Schedule, post domain.Post, scheduledAt time.Time) (domain.PostRecord, error) {
+	defer u.locks.lock(scheduleID)()
+
+	now := u.clock.Now()
+	record, err := u.repository.Find(scheduleID)
+	if err != nil {
+		return domain.PostRecord{}, err
+	}
+
+	if !u.guard.CanPost(schedule, record, scheduledAt) {
+		return domain.PostRecord{}, nil
+	}
+	if err := u.checkSilenced(scheduleID, now); err != nil {
+		return domain.PostRecord{}, err
+	}
+
+	return u.post(ctx, scheduleID, schedule, post, now, scheduledAt)
+}
+
+func (u *SchedulePostUseCase) ForceExecute(ctx context.Context, scheduleID string, schedule domain.Schedule, post domain.Post) (domain.PostRecord, error) {
+	defer u.locks.lock(scheduleID)()
+
+	return u.post(ctx, scheduleID, schedule, post, u.clock.Now(), time.Time{})
+}
+
+func (u *SchedulePostUseCase) CheckSilenced(scheduleID string) error {
+	return u.checkSilenced(scheduleID, u.clock.Now())
+}
+
+func (u *SchedulePostUseCase) checkSilenced(scheduleID string, now time.Time) error {
+	if u.pauses != nil {
+		state, err := u.pauses.Load()
+		if err != nil {
+			return fmt.Errorf("failed to read pause state: %w", err)
+		}
+		if state.IsPaused(scheduleID) {
+			return ErrPaused
+		}
+	}
+	if window, muted := domain.ActiveMuteWindow(u.mutes, now); muted {
+		return &MutedError{Window: window, Until: window.EndAfter(now)}
+	}
+	return nil
+}
+
+func (u *SchedulePostUseCase) post(ctx context.Context, scheduleID string, schedule domain.Schedule, post domain.Post, now, scheduledAt time.Time) (domain.PostRecord, error) {
+	periodTime := now
+	if !scheduledAt.IsZero() {
+		periodTime = scheduledAt
+	}
+	post.ScheduleID = scheduleID
+	if post.IdempotencyKey == "" {
+		post.IdempotencyKey = scheduleID + ":" + schedule.Period().Key(periodTime)
+	}
+
+	result, err := u.poster.Post(ctx, post)
+	latency := u.clock.Now().Sub(now)
+	if err != nil {
+		failedRecord := domain.NewFailedPostRecord(scheduleID, now, err)
+		failedRecord.Account = post.Account
+		failedRecord.Latency = latency
+		failedRecord.TextHash = post.TextHash()
+		failedRecord.ScheduledAt = scheduledAt
+		return failedRecord, errors.Join(err, u.appendHistory(failedRecord))
 	}
 
 	newRecord := domain.NewPostRecord(scheduleID, now)
-	return u.repository.Save(newRecord)
+	newRecord.Account = post.Account
+	newRecord.NoteID = result.ID
+	newRecord.Latency = latency
+	newRecord.TextHash = post.TextHash()
+	newRecord.ScheduledAt = scheduledAt
+	return newRecord, u.repository.Save(newRecord)
+}
+
+func (u *SchedulePostUseCase) appendHistory(record domain.PostRecord) error {
+	history, ok := u.repository.(ports.PostHistory)
+	if !ok {
+		return nil
+	}
+	return history.Append(record)
 }
 
 func (u *SchedulePostUseCase) ShouldExecuteNow(scheduleID string, schedule domain.Schedule, tolerance time.Duration) bool {
@@ -1,10 +1,12 @@
 package usecases_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
 	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
 	"github.com/CAT5NEKO/hijikiTool/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -20,10 +22,11 @@ func (c *FakeClock) Now() time.Time {
 }
 
 type FakePostRecordRepository struct {
-	records    map[string]domain.PostRecord
-	saveError  error
-	saveCalled bool
+	records     map[string]domain.PostRecord
+	saveError   error
+	saveCalled  bool
 	savedRecord domain.PostRecord
+	appended    []domain.PostRecord
 }
 
 func NewFakePostRecordRepository() *FakePostRecordRepository {
@@ -48,16 +51,35 @@ func (r *FakePostRecordRepository) Save(record domain.PostRecord) error {
 	return nil
 }
 
+func (r *FakePostRecordRepository) Append(record domain.PostRecord) error {
+	r.appended = append(r.appended, record)
+	return nil
+}
+
+func (r *FakePostRecordRepository) Query(query ports.PostHistoryQuery) ([]domain.PostRecord, error) {
+	return r.appended, nil
+}
+
+func (r *FakePostRecordRepository) Prune(before time.Time) (int, error) {
+	return 0, nil
+}
+
 type FakePoster struct {
-	postCalled  bool
+	postCalled    bool
 	postedContent string
-	postError   error
+	postedPost    domain.Post
+	postError     error
+	noteID        string
 }
 
-func (p *FakePoster) Post(content string) error {
+func (p *FakePoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
 	p.postCalled = true
-	p.postedContent = content
-	return p.postError
+	p.postedContent = post.Text
+	p.postedPost = post
+	if p.postError != nil {
+		return domain.PostResult{}, p.postError
+	}
+	return domain.PostResult{ID: p.noteID}, nil
 }
 
 func TestSchedulePostUseCase_Execute_WhenCanPost_PostsAndSavesRecord(t *testing.T) {
@@ -67,13 +89,14 @@ func TestSchedulePostUseCase_Execute_WhenCanPost_PostsAndSavesRecord(t *testing.
 	schedule := domain.NewDailySchedule(12, 0)
 	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
 
-	err := useCase.Execute("test-schedule", schedule, "Hello World")
+	record, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.NewTextPost("Hello World"))
 
 	require.NoError(t, err)
 	assert.True(t, poster.postCalled)
 	assert.Equal(t, "Hello World", poster.postedContent)
 	assert.True(t, repo.saveCalled)
 	assert.Equal(t, "test-schedule", repo.savedRecord.ScheduleID)
+	assert.Equal(t, repo.savedRecord, record)
 }
 
 func TestSchedulePostUseCase_Execute_WhenAlreadyPostedToday_SkipsPost(t *testing.T) {
@@ -84,11 +107,12 @@ func TestSchedulePostUseCase_Execute_WhenAlreadyPostedToday_SkipsPost(t *testing
 	schedule := domain.NewDailySchedule(12, 0)
 	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
 
-	err := useCase.Execute("test-schedule", schedule, "Hello World")
+	record, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.NewTextPost("Hello World"))
 
 	require.NoError(t, err)
 	assert.False(t, poster.postCalled)
 	assert.False(t, repo.saveCalled)
+	assert.Zero(t, record)
 }
 
 func TestSchedulePostUseCase_Execute_WhenPosterFails_ReturnsError(t *testing.T) {
@@ -98,10 +122,29 @@ func TestSchedulePostUseCase_Execute_WhenPosterFails_ReturnsError(t *testing.T)
 	schedule := domain.NewDailySchedule(12, 0)
 	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
 
-	err := useCase.Execute("test-schedule", schedule, "Hello World")
+	_, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.Post{Account: "mastodon", Text: "Hello World"})
 
 	require.Error(t, err)
 	assert.False(t, repo.saveCalled)
+	require.Len(t, repo.appended, 1)
+	assert.Equal(t, domain.PostStatusFailed, repo.appended[0].Status)
+	assert.Equal(t, "mastodon", repo.appended[0].Account)
+	assert.Equal(t, "network error", repo.appended[0].Error)
+}
+
+func TestSchedulePostUseCase_Execute_SavesAccountAndNoteID(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &FakePoster{noteID: "9abc"}
+	schedule := domain.NewDailySchedule(12, 0)
+	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
+
+	_, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.Post{Account: "mastodon", Text: "Hello World"})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.PostStatusPosted, repo.savedRecord.Status)
+	assert.Equal(t, "mastodon", repo.savedRecord.Account)
+	assert.Equal(t, "9abc", repo.savedRecord.NoteID)
 }
 
 func TestSchedulePostUseCase_Execute_WhenRepoSaveFails_ReturnsError(t *testing.T) {
@@ -112,12 +155,42 @@ func TestSchedulePostUseCase_Execute_WhenRepoSaveFails_ReturnsError(t *testing.T
 	schedule := domain.NewDailySchedule(12, 0)
 	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
 
-	err := useCase.Execute("test-schedule", schedule, "Hello World")
+	_, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.NewTextPost("Hello World"))
 
 	require.Error(t, err)
 	assert.True(t, poster.postCalled)
 }
 
+func TestSchedulePostUseCase_Execute_AssignsPeriodScopedIdempotencyKey(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &FakePoster{}
+	schedule := domain.NewDailySchedule(12, 0)
+	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
+
+	_, err := useCase.Execute(context.Background(), "test-schedule", schedule, domain.Post{Account: "mastodon", Text: "Hello World"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "test-schedule", poster.postedPost.ScheduleID)
+	assert.Equal(t, "mastodon", poster.postedPost.Account)
+	assert.Equal(t, "test-schedule:2026-02-01", poster.postedPost.IdempotencyKey)
+}
+
+func TestSchedulePostUseCase_ForceExecute_WhenAlreadyPostedToday_PostsAnyway(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 13, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	repo.records["test-schedule"] = domain.NewPostRecord("test-schedule", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+	poster := &FakePoster{}
+	schedule := domain.NewDailySchedule(12, 0)
+	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
+
+	_, err := useCase.ForceExecute(context.Background(), "test-schedule", schedule, domain.NewTextPost("Hello World"))
+
+	require.NoError(t, err)
+	assert.True(t, poster.postCalled)
+	assert.Equal(t, clock.fixedTime, repo.savedRecord.LastPostedAt)
+}
+
 func TestSchedulePostUseCase_ShouldExecuteNow_WhenTimeMatches_ReturnsTrue(t *testing.T) {
 	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 30, 0, time.UTC)}
 	repo := NewFakePostRecordRepository()
@@ -154,3 +227,93 @@ func TestSchedulePostUseCase_ShouldExecuteNow_WhenAlreadyPosted_ReturnsFalse(t *
 
 	assert.False(t, shouldExecute)
 }
+
+type FakePauseStore struct {
+	state domain.PauseState
+}
+
+func (s *FakePauseStore) Load() (domain.PauseState, error) {
+	return s.state, nil
+}
+
+func (s *FakePauseStore) Save(state domain.PauseState) error {
+	s.state = state
+	return nil
+}
+
+func TestSchedulePostUseCase_Execute_WhenPaused_ReturnsErrPausedWithoutPosting(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &FakePoster{}
+	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
+	useCase.SetPauseStore(&FakePauseStore{state: domain.PauseState{}.WithSchedule("test-schedule", true)})
+
+	_, err := useCase.Execute(context.Background(), "test-schedule", domain.NewDailySchedule(12, 0), domain.NewTextPost("Hello World"))
+
+	assert.ErrorIs(t, err, usecases.ErrPaused)
+	assert.False(t, poster.postCalled)
+	assert.False(t, repo.saveCalled)
+}
+
+func TestSchedulePostUseCase_Execute_WhenAllPaused_ReturnsErrPaused(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &FakePoster{}
+	useCase := usecases.NewSchedulePostUseCase(clock, NewFakePostRecordRepository(), poster, domain.NewPostGuard())
+	useCase.SetPauseStore(&FakePauseStore{state: domain.PauseState{All: true}})
+
+	_, err := useCase.Execute(context.Background(), "test-schedule", domain.NewDailySchedule(12, 0), domain.NewTextPost("Hello World"))
+
+	assert.ErrorIs(t, err, usecases.ErrPaused)
+	assert.False(t, poster.postCalled)
+}
+
+func TestSchedulePostUseCase_Execute_DuringMuteWindow_ReturnsMutedError(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 3, 0, 0, 0, time.UTC)}
+	poster := &FakePoster{}
+	useCase := usecases.NewSchedulePostUseCase(clock, NewFakePostRecordRepository(), poster, domain.NewPostGuard())
+	useCase.SetMuteWindows([]domain.MuteWindow{domain.NewMuteWindow(1, 0, 6, 0, domain.MutePolicyDefer)})
+
+	_, err := useCase.Execute(context.Background(), "test-schedule", domain.NewDailySchedule(3, 0), domain.NewTextPost("Hello World"))
+
+	var muted *usecases.MutedError
+	require.ErrorAs(t, err, &muted)
+	assert.ErrorIs(t, err, usecases.ErrMuted)
+	assert.Equal(t, time.Date(2026, 2, 1, 6, 0, 0, 0, time.UTC), muted.Until)
+	assert.False(t, poster.postCalled)
+}
+
+func TestSchedulePostUseCase_ForceExecute_IgnoresPauseAndMuteWindows(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 1, 3, 0, 0, 0, time.UTC)}
+	poster := &FakePoster{}
+	useCase := usecases.NewSchedulePostUseCase(clock, NewFakePostRecordRepository(), poster, domain.NewPostGuard())
+	useCase.SetPauseStore(&FakePauseStore{state: domain.PauseState{All: true}})
+	useCase.SetMuteWindows([]domain.MuteWindow{domain.NewMuteWindow(1, 0, 6, 0, domain.MutePolicySkip)})
+
+	_, err := useCase.ForceExecute(context.Background(), "test-schedule", domain.NewDailySchedule(3, 0), domain.NewTextPost("Hello World"))
+
+	require.NoError(t, err)
+	assert.True(t, poster.postCalled)
+}
+
+func TestSchedulePostUseCase_ExecuteDeferred_RecordsOriginalScheduledTime(t *testing.T) {
+	clock := &FakeClock{fixedTime: time.Date(2026, 2, 2, 6, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &FakePoster{}
+	schedule := domain.NewDailySchedule(23, 30)
+	scheduledAt := time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)
+	useCase := usecases.NewSchedulePostUseCase(clock, repo, poster, domain.NewPostGuard())
+
+	record, err := useCase.ExecuteDeferred(context.Background(), "nightly", schedule, domain.NewTextPost("おやすみ"), scheduledAt)
+
+	require.NoError(t, err)
+	assert.Equal(t, clock.fixedTime, record.LastPostedAt)
+	assert.Equal(t, scheduledAt, record.ScheduledAt)
+	assert.Equal(t, "nightly:2026-02-01", poster.postedPost.IdempotencyKey)
+
+	clock.fixedTime = time.Date(2026, 2, 2, 23, 30, 0, 0, time.UTC)
+	poster.postCalled = false
+	_, err = useCase.Execute(context.Background(), "nightly", schedule, domain.NewTextPost("おやすみ"))
+
+	require.NoError(t, err)
+	assert.True(t, poster.postCalled)
+}
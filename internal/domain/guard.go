@@ -9,10 +9,10 @@ func NewPostGuard() *PostGuard {
 }
 
 func (g *PostGuard) CanPost(schedule Schedule, record PostRecord, now time.Time) bool {
-	if record.IsZero() {
+	if record.IsZero() || record.Failed() {
 		return true
 	}
-	return g.isInNewPeriod(schedule.Period(), record.LastPostedAt, now)
+	return g.isInNewPeriod(schedule.Period(), record.PeriodTime(), now)
 }
 
 func (g *PostGuard) isInNewPeriod(period PeriodType, lastPosted, now time.Time) bool {
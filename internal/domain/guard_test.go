@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -30,6 +31,17 @@ func TestPostGuard_CanPost_DailySchedule_SameDay_ReturnsFalse(t *testing.T) {
 	assert.False(t, canPost)
 }
 
+func TestPostGuard_CanPost_DailySchedule_FailedAttemptSameDay_ReturnsTrue(t *testing.T) {
+	guard := domain.NewPostGuard()
+	schedule := domain.NewDailySchedule(12, 0)
+	now := time.Date(2026, 2, 1, 13, 0, 0, 0, time.UTC)
+	record := domain.NewFailedPostRecord("daily-12", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC), errors.New("network error"))
+
+	canPost := guard.CanPost(schedule, record, now)
+
+	assert.True(t, canPost)
+}
+
 func TestPostGuard_CanPost_DailySchedule_NextDay_ReturnsTrue(t *testing.T) {
 	guard := domain.NewPostGuard()
 	schedule := domain.NewDailySchedule(12, 0)
@@ -139,3 +151,13 @@ func TestPostGuard_CanPost_AfterServerRestart_WithOldRecord_ReturnsFalse(t *test
 
 	assert.False(t, canPost)
 }
+
+func TestPostGuard_CanPost_DeferredPastMidnight_CountsForOriginalDay(t *testing.T) {
+	guard := domain.NewPostGuard()
+	schedule := domain.NewDailySchedule(23, 30)
+	record := domain.NewPostRecord("nightly", time.Date(2026, 2, 2, 6, 0, 0, 0, time.UTC))
+	record.ScheduledAt = time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)
+
+	assert.False(t, guard.CanPost(schedule, record, time.Date(2026, 2, 1, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, guard.CanPost(schedule, record, time.Date(2026, 2, 2, 23, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type MutePolicy string
+
+const (
+	MutePolicySkip  MutePolicy = "skip"
+	MutePolicyDefer MutePolicy = "defer"
+)
+
+type MuteWindow struct {
+	start  int
+	end    int
+	Policy MutePolicy
+}
+
+func NewMuteWindow(startHour, startMinute, endHour, endMinute int, policy MutePolicy) MuteWindow {
+	return MuteWindow{start: startHour*60 + startMinute, end: endHour*60 + endMinute, Policy: policy}
+}
+
+func (w MuteWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if w.start <= w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+func (w MuteWindow) EndAfter(t time.Time) time.Time {
+	end := time.Date(t.Year(), t.Month(), t.Day(), w.end/60, w.end%60, 0, 0, t.Location())
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+func (w MuteWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+}
+
+func ActiveMuteWindow(windows []MuteWindow, t time.Time) (MuteWindow, bool) {
+	for _, window := range windows {
+		if window.Contains(t) {
+			return window, true
+		}
+	}
+	return MuteWindow{}, false
+}
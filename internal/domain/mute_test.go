@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMuteWindow_Contains_SameDayWindow(t *testing.T) {
+	window := domain.NewMuteWindow(1, 0, 6, 0, domain.MutePolicySkip)
+
+	assert.False(t, window.Contains(time.Date(2026, 2, 1, 0, 59, 0, 0, time.UTC)))
+	assert.True(t, window.Contains(time.Date(2026, 2, 1, 1, 0, 0, 0, time.UTC)))
+	assert.True(t, window.Contains(time.Date(2026, 2, 1, 5, 59, 0, 0, time.UTC)))
+	assert.False(t, window.Contains(time.Date(2026, 2, 1, 6, 0, 0, 0, time.UTC)))
+}
+
+func TestMuteWindow_Contains_WindowAcrossMidnight(t *testing.T) {
+	window := domain.NewMuteWindow(23, 0, 2, 0, domain.MutePolicySkip)
+
+	assert.True(t, window.Contains(time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)))
+	assert.True(t, window.Contains(time.Date(2026, 2, 2, 1, 30, 0, 0, time.UTC)))
+	assert.False(t, window.Contains(time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestMuteWindow_EndAfter_ReturnsNextEnd(t *testing.T) {
+	window := domain.NewMuteWindow(23, 0, 2, 0, domain.MutePolicyDefer)
+
+	assert.Equal(t, time.Date(2026, 2, 2, 2, 0, 0, 0, time.UTC), window.EndAfter(time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 2, 2, 2, 0, 0, 0, time.UTC), window.EndAfter(time.Date(2026, 2, 2, 1, 30, 0, 0, time.UTC)))
+}
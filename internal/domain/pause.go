@@ -0,0 +1,40 @@
+package domain
+
+import "sort"
+
+type PauseState struct {
+	All       bool
+	Schedules map[string]bool
+}
+
+func (s PauseState) IsPaused(scheduleID string) bool {
+	return s.All || s.Schedules[scheduleID]
+}
+
+func (s PauseState) WithSchedule(scheduleID string, paused bool) PauseState {
+	schedules := make(map[string]bool, len(s.Schedules)+1)
+	for id := range s.Schedules {
+		schedules[id] = true
+	}
+	if paused {
+		schedules[scheduleID] = true
+	} else {
+		delete(schedules, scheduleID)
+	}
+	return PauseState{All: s.All, Schedules: schedules}
+}
+
+func (s PauseState) WithAll(paused bool) PauseState {
+	return PauseState{All: paused, Schedules: s.Schedules}
+}
+
+func (s PauseState) PausedSchedules() []string {
+	ids := make([]string, 0, len(s.Schedules))
+	for id, paused := range s.Schedules {
+		if paused {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type Post struct {
+	ScheduleID     string
+	Account        string
+	Text           string
+	SpoilerText    string
+	Visibility     string
+	MediaPaths     []string
+	IdempotencyKey string
+}
+
+func NewTextPost(text string) Post {
+	return Post{Text: text}
+}
+
+func (p Post) HasMedia() bool {
+	return len(p.MediaPaths) > 0
+}
+
+func (p Post) TextHash() string {
+	sum := sha256.Sum256([]byte(p.Text))
+	return hex.EncodeToString(sum[:])
+}
+
+type PostResult struct {
+	ID string
+}
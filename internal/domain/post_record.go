@@ -2,18 +2,53 @@ package domain
 
 import "time"
 
+type PostStatus string
+
+const (
+	PostStatusPosted PostStatus = "posted"
+	PostStatusFailed PostStatus = "failed"
+)
+
 type PostRecord struct {
 	ScheduleID   string
+	Account      string
 	LastPostedAt time.Time
+	ScheduledAt  time.Time
+	NoteID       string
+	Status       PostStatus
+	Error        string
+	Latency      time.Duration
+	TextHash     string
 }
 
 func NewPostRecord(scheduleID string, lastPostedAt time.Time) PostRecord {
 	return PostRecord{
 		ScheduleID:   scheduleID,
 		LastPostedAt: lastPostedAt,
+		Status:       PostStatusPosted,
+	}
+}
+
+func NewFailedPostRecord(scheduleID string, attemptedAt time.Time, err error) PostRecord {
+	return PostRecord{
+		ScheduleID:   scheduleID,
+		LastPostedAt: attemptedAt,
+		Status:       PostStatusFailed,
+		Error:        err.Error(),
 	}
 }
 
 func (r PostRecord) IsZero() bool {
 	return r.LastPostedAt.IsZero()
 }
+
+func (r PostRecord) PeriodTime() time.Time {
+	if r.ScheduledAt.IsZero() {
+		return r.LastPostedAt
+	}
+	return r.ScheduledAt
+}
+
+func (r PostRecord) Failed() bool {
+	return r.Status == PostStatusFailed
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PeriodType int
 
@@ -11,10 +14,27 @@ const (
 	PeriodYearly
 )
 
+func (p PeriodType) Key(t time.Time) string {
+	switch p {
+	case PeriodDaily:
+		return t.Format("2006-01-02")
+	case PeriodWeekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case PeriodMonthly:
+		return t.Format("2006-01")
+	case PeriodYearly:
+		return t.Format("2006")
+	default:
+		return t.Format(time.RFC3339)
+	}
+}
+
 type Schedule interface {
 	NextTime(now time.Time) time.Time
 	DurationUntil(now time.Time) time.Duration
 	Period() PeriodType
+	String() string
 }
 
 type DailySchedule struct {
@@ -42,6 +62,10 @@ func (s *DailySchedule) Period() PeriodType {
 	return PeriodDaily
 }
 
+func (s *DailySchedule) String() string {
+	return fmt.Sprintf("daily %02d:%02d", s.hour, s.minute)
+}
+
 type WeeklySchedule struct {
 	dayOfWeek time.Weekday
 	hour      int
@@ -79,6 +103,10 @@ func (s *WeeklySchedule) Period() PeriodType {
 	return PeriodWeekly
 }
 
+func (s *WeeklySchedule) String() string {
+	return fmt.Sprintf("weekly %s %02d:%02d", s.dayOfWeek.String()[:3], s.hour, s.minute)
+}
+
 type MonthlySchedule struct {
 	dayOfMonth int
 	hour       int
@@ -122,6 +150,10 @@ func (s *MonthlySchedule) Period() PeriodType {
 	return PeriodMonthly
 }
 
+func (s *MonthlySchedule) String() string {
+	return fmt.Sprintf("monthly day %d %02d:%02d", s.dayOfMonth, s.hour, s.minute)
+}
+
 type YearlySchedule struct {
 	month  time.Month
 	day    int
@@ -157,3 +189,7 @@ func (s *YearlySchedule) DurationUntil(now time.Time) time.Duration {
 func (s *YearlySchedule) Period() PeriodType {
 	return PeriodYearly
 }
+
+func (s *YearlySchedule) String() string {
+	return fmt.Sprintf("yearly %s %d %02d:%02d", s.month.String()[:3], s.day, s.hour, s.minute)
+}
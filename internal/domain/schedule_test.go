@@ -165,3 +165,27 @@ func TestSchedule_DurationUntil_ReturnsPositiveDuration(t *testing.T) {
 	require.True(t, duration > 0)
 	assert.Equal(t, time.Hour, duration)
 }
+
+func TestPeriodType_Key_ReturnsSameKeyWithinPeriod(t *testing.T) {
+	morning := time.Date(2026, 2, 2, 8, 0, 0, 0, time.UTC)
+	evening := time.Date(2026, 2, 2, 20, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-02-02", domain.PeriodDaily.Key(morning))
+	assert.Equal(t, domain.PeriodDaily.Key(morning), domain.PeriodDaily.Key(evening))
+	assert.Equal(t, "2026-W06", domain.PeriodWeekly.Key(morning))
+	assert.Equal(t, "2026-02", domain.PeriodMonthly.Key(morning))
+	assert.Equal(t, "2026", domain.PeriodYearly.Key(morning))
+}
+
+func TestPeriodType_Key_WeeklyCrossingYearBoundary_UsesISOYear(t *testing.T) {
+	newYearsDay := time.Date(2027, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-W53", domain.PeriodWeekly.Key(newYearsDay))
+}
+
+func TestSchedule_String_DescribesSchedule(t *testing.T) {
+	assert.Equal(t, "daily 12:37", domain.NewDailySchedule(12, 37).String())
+	assert.Equal(t, "weekly Mon 09:00", domain.NewWeeklySchedule(time.Monday, 9, 0).String())
+	assert.Equal(t, "monthly day 31 00:05", domain.NewMonthlySchedule(31, 0, 5).String())
+	assert.Equal(t, "yearly Jan 1 00:00", domain.NewYearlySchedule(time.January, 1, 0, 0).String())
+}
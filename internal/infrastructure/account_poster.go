@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+const DefaultAccountName = "default"
+
+type AccountPoster struct {
+	posters map[string]ports.Poster
+}
+
+func NewAccountPoster(defaultPoster ports.Poster, accounts map[string]ports.Poster) ports.Poster {
+	posters := make(map[string]ports.Poster, len(accounts)+1)
+	for name, poster := range accounts {
+		posters[name] = poster
+	}
+	posters[DefaultAccountName] = defaultPoster
+	return &AccountPoster{posters: posters}
+}
+
+func (p *AccountPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	accountName := post.Account
+	if accountName == "" {
+		accountName = DefaultAccountName
+	}
+
+	poster, exists := p.posters[accountName]
+	if !exists {
+		return domain.PostResult{}, fmt.Errorf("unknown account: %s", accountName)
+	}
+
+	return poster.Post(ctx, post)
+}
+
+func NewPosterForAccount(account AccountConfig) (ports.Poster, error) {
+	switch account.Type {
+	case "", "misskey":
+		return NewMisskeyPoster(ports.Config{
+			MisskeyHost:  account.Host,
+			MisskeyToken: account.Token,
+			Visibility:   defaultString(account.Visibility, "home"),
+			LocalOnly:    account.LocalOnly,
+		}), nil
+	case "mastodon":
+		return NewMastodonPoster(MastodonConfig{
+			Host:       account.Host,
+			Token:      account.Token,
+			Visibility: defaultString(account.Visibility, "home"),
+		}), nil
+	case "bluesky":
+		return NewBlueskyPoster(BlueskyConfig{
+			Host:        account.Host,
+			Identifier:  account.Handle,
+			AppPassword: account.Token,
+		}), nil
+	case "webhook":
+		return NewWebhookPoster(WebhookConfig{
+			URL:          account.URL,
+			Provider:     account.Provider,
+			Headers:      account.Headers,
+			BodyTemplate: account.BodyTemplate,
+		})
+	default:
+		return nil, fmt.Errorf("unknown account type for %s: %s", account.Name, account.Type)
+	}
+}
+
+func NewPostersForAccounts(accounts []AccountConfig) (map[string]ports.Poster, error) {
+	posters := make(map[string]ports.Poster, len(accounts))
+	for _, account := range accounts {
+		poster, err := NewPosterForAccount(account)
+		if err != nil {
+			return nil, err
+		}
+		posters[account.Name] = poster
+	}
+	return posters, nil
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,59 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPoster struct {
+	posts []domain.Post
+}
+
+func (p *recordingPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.posts = append(p.posts, post)
+	return domain.PostResult{}, nil
+}
+
+func TestAccountPoster_Post_WithoutAccount_UsesDefaultPoster(t *testing.T) {
+	defaultPoster := &recordingPoster{}
+	mastodonPoster := &recordingPoster{}
+	poster := infrastructure.NewAccountPoster(defaultPoster, map[string]ports.Poster{"mastodon": mastodonPoster})
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.NoError(t, err)
+	assert.Len(t, defaultPoster.posts, 1)
+	assert.Empty(t, mastodonPoster.posts)
+}
+
+func TestAccountPoster_Post_WithAccount_RoutesToNamedPoster(t *testing.T) {
+	defaultPoster := &recordingPoster{}
+	mastodonPoster := &recordingPoster{}
+	poster := infrastructure.NewAccountPoster(defaultPoster, map[string]ports.Poster{"mastodon": mastodonPoster})
+
+	_, err := poster.Post(context.Background(), domain.Post{Account: "mastodon", Text: "おひ"})
+
+	require.NoError(t, err)
+	assert.Empty(t, defaultPoster.posts)
+	assert.Len(t, mastodonPoster.posts, 1)
+}
+
+func TestAccountPoster_Post_WithUnknownAccount_ReturnsError(t *testing.T) {
+	poster := infrastructure.NewAccountPoster(&recordingPoster{}, nil)
+
+	_, err := poster.Post(context.Background(), domain.Post{Account: "bluesky", Text: "おひ"})
+
+	require.Error(t, err)
+}
+
+func TestNewPosterForAccount_UnknownType_ReturnsError(t *testing.T) {
+	_, err := infrastructure.NewPosterForAccount(infrastructure.AccountConfig{Name: "x", Type: "myspace"})
+
+	require.Error(t, err)
+}
@@ -0,0 +1,333 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/rivo/uniseg"
+)
+
+const (
+	blueskyDefaultHost    = "bsky.social"
+	blueskyMaxGraphemes   = 300
+	blueskyPostCollection = "app.bsky.feed.post"
+)
+
+var (
+	errBlueskySessionExpired = errors.New("bluesky session expired")
+
+	blueskyMentionPattern = regexp.MustCompile(`(?:^|[\s(])(@(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)`)
+	blueskyLinkPattern    = regexp.MustCompile(`(?:^|[\s(])(https?://[^\s]+)`)
+	blueskyTagPattern     = regexp.MustCompile(`(?:^|\s)([#＃][^\s#＃]+)`)
+	blueskyDigitsPattern  = regexp.MustCompile(`^[0-9]+$`)
+)
+
+type BlueskyConfig struct {
+	Host        string
+	Identifier  string
+	AppPassword string
+}
+
+type BlueskyPoster struct {
+	host        string
+	identifier  string
+	appPassword string
+	httpClient  *http.Client
+	now         func() time.Time
+	mutex       sync.Mutex
+	session     blueskySession
+}
+
+type blueskySession struct {
+	AccessJwt  string `json:"accessJwt"`
+	RefreshJwt string `json:"refreshJwt"`
+	DID        string `json:"did"`
+}
+
+type blueskyCreateSessionRequest struct {
+	Identifier string `json:"identifier"`
+	Password   string `json:"password"`
+}
+
+type blueskyCreateRecordRequest struct {
+	Repo       string            `json:"repo"`
+	Collection string            `json:"collection"`
+	Record     blueskyPostRecord `json:"record"`
+}
+
+type blueskyPostRecord struct {
+	Type      string         `json:"$type"`
+	Text      string         `json:"text"`
+	CreatedAt string         `json:"createdAt"`
+	Facets    []blueskyFacet `json:"facets,omitempty"`
+}
+
+type blueskyFacet struct {
+	Index    blueskyByteSlice `json:"index"`
+	Features []blueskyFeature `json:"features"`
+}
+
+type blueskyByteSlice struct {
+	ByteStart int `json:"byteStart"`
+	ByteEnd   int `json:"byteEnd"`
+}
+
+type blueskyFeature struct {
+	Type string `json:"$type"`
+	URI  string `json:"uri,omitempty"`
+	Tag  string `json:"tag,omitempty"`
+	DID  string `json:"did,omitempty"`
+}
+
+type blueskyCreateRecordResponse struct {
+	URI string `json:"uri"`
+	CID string `json:"cid"`
+}
+
+type blueskyErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type blueskyAPIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *blueskyAPIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("request failed with status code: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("request failed with status code: %d %s", e.StatusCode, e.Code)
+}
+
+type blueskyResolveHandleResponse struct {
+	DID string `json:"did"`
+}
+
+func NewBlueskyPoster(config BlueskyConfig) ports.Poster {
+	return &BlueskyPoster{
+		host:        defaultString(config.Host, blueskyDefaultHost),
+		identifier:  config.Identifier,
+		appPassword: config.AppPassword,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		now:         time.Now,
+	}
+}
+
+func (p *BlueskyPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	if post.SpoilerText != "" {
+		return domain.PostResult{}, errors.New("bluesky does not support spoilerText")
+	}
+	if len(post.MediaPaths) > 0 {
+		return domain.PostResult{}, errors.New("bluesky does not support media attachments")
+	}
+	graphemes := uniseg.GraphemeClusterCount(post.Text)
+	if graphemes > blueskyMaxGraphemes {
+		return domain.PostResult{}, fmt.Errorf("post text exceeds %d graphemes: %d", blueskyMaxGraphemes, graphemes)
+	}
+	facets := p.detectFacets(ctx, post.Text)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.session.AccessJwt == "" {
+		if err := p.createSession(ctx); err != nil {
+			return domain.PostResult{}, err
+		}
+	}
+
+	result, err := p.createPostRecord(ctx, post.Text, facets)
+	if !errors.Is(err, errBlueskySessionExpired) {
+		return result, err
+	}
+
+	if err := p.refreshSession(ctx); err != nil {
+		if err := p.createSession(ctx); err != nil {
+			return domain.PostResult{}, err
+		}
+	}
+	return p.createPostRecord(ctx, post.Text, facets)
+}
+
+func (p *BlueskyPoster) createSession(ctx context.Context) error {
+	request := blueskyCreateSessionRequest{Identifier: p.identifier, Password: p.appPassword}
+	var session blueskySession
+	if err := p.call(ctx, "com.atproto.server.createSession", "", request, &session); err != nil {
+		var apiErr *blueskyAPIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			return fmt.Errorf("failed to create bluesky session for %s (check the app password): %w: %v", p.identifier, ErrCredentialsRejected, err)
+		}
+		return fmt.Errorf("failed to create bluesky session: %w", err)
+	}
+	p.session = session
+	return nil
+}
+
+func (p *BlueskyPoster) refreshSession(ctx context.Context) error {
+	if p.session.RefreshJwt == "" {
+		return errBlueskySessionExpired
+	}
+	var session blueskySession
+	if err := p.call(ctx, "com.atproto.server.refreshSession", p.session.RefreshJwt, nil, &session); err != nil {
+		p.session = blueskySession{}
+		return fmt.Errorf("failed to refresh bluesky session: %w", err)
+	}
+	p.session = session
+	return nil
+}
+
+func (p *BlueskyPoster) createPostRecord(ctx context.Context, text string, facets []blueskyFacet) (domain.PostResult, error) {
+	request := blueskyCreateRecordRequest{
+		Repo:       p.session.DID,
+		Collection: blueskyPostCollection,
+		Record: blueskyPostRecord{
+			Type:      blueskyPostCollection,
+			Text:      text,
+			CreatedAt: p.now().UTC().Format(time.RFC3339),
+			Facets:    facets,
+		},
+	}
+	var response blueskyCreateRecordResponse
+	if err := p.call(ctx, "com.atproto.repo.createRecord", p.session.AccessJwt, request, &response); err != nil {
+		if blueskySessionExpired(err) {
+			return domain.PostResult{}, fmt.Errorf("%w: %v", errBlueskySessionExpired, err)
+		}
+		return domain.PostResult{}, fmt.Errorf("failed to create bluesky post: %w", err)
+	}
+	return domain.PostResult{ID: response.URI}, nil
+}
+
+func (p *BlueskyPoster) detectFacets(ctx context.Context, text string) []blueskyFacet {
+	var facets []blueskyFacet
+
+	for _, match := range blueskyMentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+		did, err := p.resolveHandle(ctx, text[start+1:end])
+		if err != nil {
+			continue
+		}
+		facets = append(facets, newBlueskyFacet(start, end, blueskyFeature{Type: "app.bsky.richtext.facet#mention", DID: did}))
+	}
+
+	for _, match := range blueskyLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], trimTrailingPunctuation(text, match[2], match[3])
+		facets = append(facets, newBlueskyFacet(start, end, blueskyFeature{Type: "app.bsky.richtext.facet#link", URI: text[start:end]}))
+	}
+
+	for _, match := range blueskyTagPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], trimTrailingPunctuation(text, match[2], match[3])
+		_, hashSize := utf8.DecodeRuneInString(text[start:end])
+		tag := text[start+hashSize : end]
+		if tag == "" || blueskyDigitsPattern.MatchString(tag) {
+			continue
+		}
+		facets = append(facets, newBlueskyFacet(start, end, blueskyFeature{Type: "app.bsky.richtext.facet#tag", Tag: tag}))
+	}
+
+	return facets
+}
+
+func (p *BlueskyPoster) resolveHandle(ctx context.Context, handle string) (string, error) {
+	endpoint := "com.atproto.identity.resolveHandle?handle=" + url.QueryEscape(handle)
+	var response blueskyResolveHandleResponse
+	if err := p.get(ctx, endpoint, &response); err != nil {
+		return "", err
+	}
+	return response.DID, nil
+}
+
+func (p *BlueskyPoster) call(ctx context.Context, method, token string, request any, response any) error {
+	var body bytes.Buffer
+	if request != nil {
+		if err := json.NewEncoder(&body).Encode(request); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.xrpcURL(method), &body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return p.do(req, response)
+}
+
+func (p *BlueskyPoster) get(ctx context.Context, method string, response any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", p.xrpcURL(method), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	return p.do(req, response)
+}
+
+func (p *BlueskyPoster) do(req *http.Request, response any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errorResponse blueskyErrorResponse
+		json.NewDecoder(resp.Body).Decode(&errorResponse)
+		return &blueskyAPIError{StatusCode: resp.StatusCode, Code: errorResponse.Error, Message: errorResponse.Message}
+	}
+
+	if response == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func blueskySessionExpired(err error) bool {
+	var apiErr *blueskyAPIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.Code == "ExpiredToken" || apiErr.Code == "InvalidToken"
+}
+
+func (p *BlueskyPoster) xrpcURL(method string) string {
+	return fmt.Sprintf("%s/xrpc/%s", baseURL(p.host), method)
+}
+
+func newBlueskyFacet(start, end int, feature blueskyFeature) blueskyFacet {
+	return blueskyFacet{
+		Index:    blueskyByteSlice{ByteStart: start, ByteEnd: end},
+		Features: []blueskyFeature{feature},
+	}
+}
+
+func trimTrailingPunctuation(text string, start, end int) int {
+	for end > start {
+		last, size := utf8.DecodeLastRuneInString(text[start:end])
+		if !strings.ContainsRune(".,;:!?)」』。、！？", last) {
+			break
+		}
+		end -= size
+	}
+	return end
+}
@@ -0,0 +1,231 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blueskyStandIn struct {
+	server          *httptest.Server
+	records         []map[string]any
+	sessionsCreated int
+	refreshes       int
+	resolutions     int
+	expireNextPost  bool
+}
+
+func newBlueskyStandIn(t *testing.T) *blueskyStandIn {
+	t.Helper()
+	standIn := &blueskyStandIn{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/xrpc/com.atproto.server.createSession", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["password"] != "app-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "AuthenticationRequired"})
+			return
+		}
+		standIn.sessionsCreated++
+		json.NewEncoder(w).Encode(map[string]string{"accessJwt": "access-1", "refreshJwt": "refresh-1", "did": "did:plc:hijiki"})
+	})
+	mux.HandleFunc("/xrpc/com.atproto.server.refreshSession", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer refresh-1", r.Header.Get("Authorization"))
+		standIn.refreshes++
+		json.NewEncoder(w).Encode(map[string]string{"accessJwt": "access-2", "refreshJwt": "refresh-2", "did": "did:plc:hijiki"})
+	})
+	mux.HandleFunc("/xrpc/com.atproto.identity.resolveHandle", func(w http.ResponseWriter, r *http.Request) {
+		standIn.resolutions++
+		if r.URL.Query().Get("handle") != "cat5neko.bsky.social" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "InvalidRequest"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"did": "did:plc:cat5neko"})
+	})
+	mux.HandleFunc("/xrpc/com.atproto.repo.createRecord", func(w http.ResponseWriter, r *http.Request) {
+		if standIn.expireNextPost {
+			standIn.expireNextPost = false
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "ExpiredToken"})
+			return
+		}
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		standIn.records = append(standIn.records, body)
+		json.NewEncoder(w).Encode(map[string]string{"uri": "at://did:plc:hijiki/app.bsky.feed.post/1", "cid": "cid"})
+	})
+	standIn.server = httptest.NewServer(mux)
+	t.Cleanup(standIn.server.Close)
+	return standIn
+}
+
+func newTestBlueskyPoster(standIn *blueskyStandIn) *infrastructure.BlueskyPoster {
+	return infrastructure.NewBlueskyPoster(infrastructure.BlueskyConfig{
+		Host:        standIn.server.URL,
+		Identifier:  "hijiki.bsky.social",
+		AppPassword: "app-password",
+	}).(*infrastructure.BlueskyPoster)
+}
+
+func facetsOf(t *testing.T, request map[string]any) []map[string]any {
+	t.Helper()
+	record := request["record"].(map[string]any)
+	rawFacets, _ := record["facets"].([]any)
+	facets := make([]map[string]any, 0, len(rawFacets))
+	for _, rawFacet := range rawFacets {
+		facet := rawFacet.(map[string]any)
+		index := facet["index"].(map[string]any)
+		feature := facet["features"].([]any)[0].(map[string]any)
+		facets = append(facets, map[string]any{
+			"start":   int(index["byteStart"].(float64)),
+			"end":     int(index["byteEnd"].(float64)),
+			"feature": feature,
+		})
+	}
+	return facets
+}
+
+func TestBlueskyPoster_Post_CreatesSessionAndPostRecord(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, standIn.sessionsCreated)
+	require.Len(t, standIn.records, 1)
+	assert.Equal(t, "did:plc:hijiki", standIn.records[0]["repo"])
+	assert.Equal(t, "app.bsky.feed.post", standIn.records[0]["collection"])
+	record := standIn.records[0]["record"].(map[string]any)
+	assert.Equal(t, "おひ", record["text"])
+	assert.Equal(t, "app.bsky.feed.post", record["$type"])
+}
+
+func TestBlueskyPoster_Post_ReusesSessionAcrossPosts(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("1"))
+	require.NoError(t, err)
+	_, err = poster.Post(context.Background(), domain.NewTextPost("2"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, standIn.sessionsCreated)
+	assert.Len(t, standIn.records, 2)
+}
+
+func TestBlueskyPoster_Post_WhenTokenExpired_RefreshesAndRetries(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+	_, err := poster.Post(context.Background(), domain.NewTextPost("1"))
+	require.NoError(t, err)
+	standIn.expireNextPost = true
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost("2"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, standIn.refreshes)
+	assert.Len(t, standIn.records, 2)
+}
+
+func TestBlueskyPoster_Post_WhenTokenExpired_ResolvesMentionsOnce(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+	_, err := poster.Post(context.Background(), domain.NewTextPost("1"))
+	require.NoError(t, err)
+	standIn.expireNextPost = true
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost("@cat5neko.bsky.social ひじき"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, standIn.resolutions)
+	assert.Len(t, facetsOf(t, standIn.records[1]), 1)
+}
+
+func TestBlueskyPoster_Post_WithWrongPassword_ReturnsError(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := infrastructure.NewBlueskyPoster(infrastructure.BlueskyConfig{Host: standIn.server.URL, Identifier: "hijiki", AppPassword: "wrong"})
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.ErrorIs(t, err, infrastructure.ErrCredentialsRejected)
+	assert.NotContains(t, err.Error(), "expired")
+	assert.Empty(t, standIn.records)
+}
+
+func TestBlueskyPoster_Post_ComputesFacetsWithUTF8ByteOffsets(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+	text := "ひじき https://example.com/hijiki. #ひじき @cat5neko.bsky.social @unknown.example"
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost(text))
+
+	require.NoError(t, err)
+	facets := facetsOf(t, standIn.records[0])
+	require.Len(t, facets, 3)
+
+	mention := facets[0]
+	assert.Equal(t, "@cat5neko.bsky.social", text[mention["start"].(int):mention["end"].(int)])
+	assert.Equal(t, "did:plc:cat5neko", mention["feature"].(map[string]any)["did"])
+
+	link := facets[1]
+	assert.Equal(t, strings.Index(text, "https://"), link["start"])
+	assert.Equal(t, "https://example.com/hijiki", text[link["start"].(int):link["end"].(int)])
+	assert.Equal(t, "https://example.com/hijiki", link["feature"].(map[string]any)["uri"])
+
+	tag := facets[2]
+	assert.Equal(t, "#ひじき", text[tag["start"].(int):tag["end"].(int)])
+	assert.Equal(t, "ひじき", tag["feature"].(map[string]any)["tag"])
+}
+
+func TestBlueskyPoster_Post_IgnoresNumericHashtags(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("issue #123"))
+
+	require.NoError(t, err)
+	assert.Empty(t, facetsOf(t, standIn.records[0]))
+}
+
+func TestBlueskyPoster_Post_CountsGraphemesNotBytes(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+	family := "👨‍👩‍👧"
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost(strings.Repeat(family, 300)))
+
+	require.NoError(t, err)
+}
+
+func TestBlueskyPoster_Post_WithSpoilerTextOrMedia_ReturnsErrorWithoutRequest(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+
+	_, spoilerErr := poster.Post(context.Background(), domain.Post{Text: "ひじき", SpoilerText: "ネタバレ"})
+	_, mediaErr := poster.Post(context.Background(), domain.Post{Text: "ひじき", MediaPaths: []string{"hijiki.png"}})
+
+	require.Error(t, spoilerErr)
+	require.Error(t, mediaErr)
+	assert.Equal(t, 0, standIn.sessionsCreated)
+}
+
+func TestBlueskyPoster_Post_OverGraphemeLimit_ReturnsErrorWithoutRequest(t *testing.T) {
+	standIn := newBlueskyStandIn(t)
+	poster := newTestBlueskyPoster(standIn)
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost(strings.Repeat("ひ", 301)))
+
+	require.Error(t, err)
+	assert.Equal(t, 0, standIn.sessionsCreated)
+}
@@ -0,0 +1,175 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigFormat string
+
+const (
+	ConfigFormatJSON ConfigFormat = "json"
+	ConfigFormatYAML ConfigFormat = "yaml"
+	ConfigFormatTOML ConfigFormat = "toml"
+)
+
+var DefaultConfigFileNames = []string{"config.json", "config.yaml", "config.yml", "config.toml"}
+
+func ConfigFormatOf(path string) (ConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ConfigFormatJSON, nil
+	case ".yaml", ".yml":
+		return ConfigFormatYAML, nil
+	case ".toml":
+		return ConfigFormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported config file extension: %s", path)
+	}
+}
+
+func FindDefaultConfigFile(dir string) string {
+	for _, name := range DefaultConfigFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, DefaultConfigFileNames[0])
+}
+
+func ConvertConfigFile(inputPath, outputPath string) error {
+	configFile, err := readSingleConfigFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, err := ConfigFormatOf(outputPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeConfigFile(outputFormat, configFile)
+	if err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+func decodeConfigFile(format ConfigFormat, data []byte, configFile *scheduleConfigFile) error {
+	var unmarshal func([]byte, any) error
+	switch format {
+	case ConfigFormatJSON:
+		unmarshal = json.Unmarshal
+	case ConfigFormatYAML:
+		unmarshal = yaml.Unmarshal
+	case ConfigFormatTOML:
+		unmarshal = toml.Unmarshal
+	default:
+		return fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	var raw map[string]any
+	if err := unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if err := checkKnownFields(raw, reflect.TypeOf(*configFile), "$"); err != nil {
+		return err
+	}
+	return unmarshal(data, configFile)
+}
+
+func checkKnownFields(value any, valueType reflect.Type, path string) error {
+	switch valueType.Kind() {
+	case reflect.Struct:
+		object, isObject := value.(map[string]any)
+		if !isObject {
+			return nil
+		}
+		fields := make(map[string]reflect.Type, valueType.NumField())
+		for index := 0; index < valueType.NumField(); index++ {
+			field := valueType.Field(index)
+			if name := jsonFieldName(field); field.IsExported() && name != "" {
+				fields[name] = field.Type
+			}
+		}
+
+		keys := sortedKeys(object)
+		for _, key := range keys {
+			fieldType, known := fields[key]
+			if !known {
+				return unknownFieldError(key, path, fields)
+			}
+			if err := checkKnownFields(object[key], fieldType, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		items, isArray := value.([]any)
+		if !isArray {
+			if tables, isTables := value.([]map[string]any); isTables {
+				for index, table := range tables {
+					if err := checkKnownFields(table, valueType.Elem(), fmt.Sprintf("%s[%d]", path, index)); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		for index, item := range items {
+			if err := checkKnownFields(item, valueType.Elem(), fmt.Sprintf("%s[%d]", path, index)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unknownFieldError(key, path string, fields map[string]reflect.Type) error {
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return fmt.Errorf("unknown field %q at %s (did you mean %q?)", key, path, name)
+		}
+	}
+	return fmt.Errorf("unknown field %q at %s", key, path)
+}
+
+func encodeConfigFile(format ConfigFormat, configFile scheduleConfigFile) ([]byte, error) {
+	var buffer bytes.Buffer
+	switch format {
+	case ConfigFormatJSON:
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(configFile); err != nil {
+			return nil, err
+		}
+	case ConfigFormatYAML:
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(configFile); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	case ConfigFormatTOML:
+		encoder := toml.NewEncoder(&buffer)
+		encoder.Indent = ""
+		if err := encoder.Encode(configFile); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+	return buffer.Bytes(), nil
+}
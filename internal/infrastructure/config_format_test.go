@@ -0,0 +1,140 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfig = `# 毎日のひじき
+accounts:
+  - name: mastodon
+    type: mastodon
+    host: mastodon.example
+    token: t
+schedules:
+  - id: daily-hijiki
+    type: daily
+    hour: 12
+    minute: 37
+    account: mastodon
+    content: |-
+      人生のネタバレ
+      「ひじき」っぽいな。
+  - id: weekly
+    type: weekly
+    dayOfWeek: 1
+    hour: 9
+    minute: 0
+    content: TODAY IS FRIDAY IN CALIFORNIA
+`
+
+const tomlConfig = `# 毎日のひじき
+[[accounts]]
+name = "mastodon"
+type = "mastodon"
+host = "mastodon.example"
+token = "t"
+
+[[schedules]]
+id = "daily-hijiki"
+type = "daily"
+hour = 12
+minute = 37
+account = "mastodon"
+content = """
+人生のネタバレ
+「ひじき」っぽいな。"""
+
+[[schedules]]
+id = "weekly"
+type = "weekly"
+dayOfWeek = 1
+hour = 9
+minute = 0
+content = "TODAY IS FRIDAY IN CALIFORNIA"
+`
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func assertSampleSchedules(t *testing.T, configs []infrastructure.ScheduleConfig) {
+	t.Helper()
+	require.Len(t, configs, 2)
+	assert.Equal(t, "daily-hijiki", configs[0].ID)
+	assert.Equal(t, "人生のネタバレ\n「ひじき」っぽいな。", configs[0].Content)
+	assert.Equal(t, "mastodon", configs[0].Account)
+	assert.Equal(t, "daily 12:37", configs[0].Schedule.String())
+	assert.Equal(t, domain.PeriodWeekly, configs[1].Schedule.Period())
+}
+
+func TestScheduleConfigLoader_Load_YAML(t *testing.T) {
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		t.Run(name, func(t *testing.T) {
+			configs, err := infrastructure.NewScheduleConfigLoader(writeConfigFile(t, name, yamlConfig)).Load()
+
+			require.NoError(t, err)
+			assertSampleSchedules(t, configs)
+		})
+	}
+}
+
+func TestScheduleConfigLoader_Load_TOML(t *testing.T) {
+	configs, err := infrastructure.NewScheduleConfigLoader(writeConfigFile(t, "config.toml", tomlConfig)).Load()
+
+	require.NoError(t, err)
+	assertSampleSchedules(t, configs)
+}
+
+func TestScheduleConfigLoader_Validate_YAMLUsesSameRules(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "schedules:\n  - id: a\n    type: daily\n    hour: 25\n    minute: 0\n    content: x\n")
+
+	issues, err := infrastructure.NewScheduleConfigLoader(path).Validate()
+
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "$.schedules[0].hour", issues[0].Path)
+}
+
+func TestScheduleConfigLoader_Load_UnsupportedExtension_ReturnsError(t *testing.T) {
+	_, err := infrastructure.NewScheduleConfigLoader(writeConfigFile(t, "config.ini", "")).Load()
+
+	require.Error(t, err)
+}
+
+func TestConvertConfigFile_RoundTripsThroughAllFormats(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := writeConfigFile(t, "config.yaml", yamlConfig)
+	tomlPath := filepath.Join(dir, "config.toml")
+	jsonPath := filepath.Join(dir, "config.json")
+
+	require.NoError(t, infrastructure.ConvertConfigFile(yamlPath, tomlPath))
+	require.NoError(t, infrastructure.ConvertConfigFile(tomlPath, jsonPath))
+
+	configs, err := infrastructure.NewScheduleConfigLoader(jsonPath).Load()
+	require.NoError(t, err)
+	assertSampleSchedules(t, configs)
+	accounts, err := infrastructure.NewScheduleConfigLoader(jsonPath).LoadAccounts()
+	require.NoError(t, err)
+	assert.Equal(t, "mastodon.example", accounts[0].Host)
+}
+
+func TestFindDefaultConfigFile_PrefersJSONThenYAMLThenTOML(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, filepath.Join(dir, "config.json"), infrastructure.FindDefaultConfigFile(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.toml"), nil, 0644))
+	assert.Equal(t, filepath.Join(dir, "config.toml"), infrastructure.FindDefaultConfigFile(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), nil, 0644))
+	assert.Equal(t, filepath.Join(dir, "config.yaml"), infrastructure.FindDefaultConfigFile(dir))
+}
@@ -0,0 +1,213 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const configSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	AllOf                []*jsonSchema          `json:"allOf,omitempty"`
+	If                   *jsonSchema            `json:"if,omitempty"`
+	Then                 *jsonSchema            `json:"then,omitempty"`
+	Not                  *jsonSchema            `json:"not,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+type configSchemaField struct {
+	description string
+	enum        []string
+	minimum     *int
+	maximum     *int
+	required    bool
+}
+
+var configSchemaDefinitions = map[reflect.Type]string{
+	reflect.TypeOf(accountConfigEntry{}):  "account",
+	reflect.TypeOf(scheduleConfigEntry{}): "schedule",
+	reflect.TypeOf(muteWindowEntry{}):     "muteWindow",
+	reflect.TypeOf(alertsEntry{}):         "alerts",
+}
+
+var configSchemaFields = map[string]configSchemaField{
+	"scheduleConfigFile.$schema":     {description: "JSON Schema used by editors; ignored by hijiki"},
+	"scheduleConfigFile.include":     {description: "Config files to merge, relative to this file; glob patterns are allowed"},
+	"scheduleConfigFile.accounts":    {description: "Accounts that schedules can post to"},
+	"scheduleConfigFile.schedules":   {description: "Scheduled posts"},
+	"scheduleConfigFile.muteWindows": {description: "Quiet hours during which scheduled posts are skipped or deferred"},
+	"scheduleConfigFile.alerts":      {description: "Notify an admin when scheduled posts keep failing"},
+
+	"accountConfigEntry.name":         {description: "Name referenced by schedules; \"default\" is reserved for the .env account", required: true},
+	"accountConfigEntry.type":         {description: "Service to post to (default: misskey)", enum: sortedKeys(supportedAccountTypes)},
+	"accountConfigEntry.host":         {description: "Instance host name or URL; may contain ${VAR}"},
+	"accountConfigEntry.handle":       {description: "Bluesky handle or DID; may contain ${VAR}"},
+	"accountConfigEntry.token":        {description: "Access token (app password for Bluesky); may contain ${VAR}"},
+	"accountConfigEntry.visibility":   {description: "Default visibility (default: home)", enum: sortedKeys(supportedVisibilities)},
+	"accountConfigEntry.localOnly":    {description: "Post as local only (Misskey)"},
+	"accountConfigEntry.provider":     {description: "Webhook payload preset (default: generic)", enum: sortedKeys(webhookBodyTemplates)},
+	"accountConfigEntry.url":          {description: "Webhook URL; may contain ${VAR}"},
+	"accountConfigEntry.headers":      {description: "Extra HTTP headers sent with webhooks; values may contain ${VAR}"},
+	"accountConfigEntry.bodyTemplate": {description: "text/template producing the JSON webhook body"},
+
+	"scheduleConfigEntry.id":          {description: "Unique schedule ID", required: true},
+	"scheduleConfigEntry.type":        {description: "How often to post", enum: sortedKeys(supportedScheduleTypes), required: true},
+	"scheduleConfigEntry.hour":        {description: "Hour of the day", minimum: intPointer(0), maximum: intPointer(23)},
+	"scheduleConfigEntry.minute":      {description: "Minute of the hour", minimum: intPointer(0), maximum: intPointer(59)},
+	"scheduleConfigEntry.dayOfWeek":   {description: "Day of the week for weekly schedules, 0 (Sunday) to 6 (Saturday)", minimum: intPointer(0), maximum: intPointer(6)},
+	"scheduleConfigEntry.dayOfMonth":  {description: "Day of the month for monthly and yearly schedules", minimum: intPointer(1), maximum: intPointer(31)},
+	"scheduleConfigEntry.month":       {description: "Month for yearly schedules", minimum: intPointer(1), maximum: intPointer(12)},
+	"scheduleConfigEntry.content":     {description: "Text to post; may be empty when media is attached"},
+	"scheduleConfigEntry.account":     {description: "Account name to post with (default: the .env account)"},
+	"scheduleConfigEntry.spoilerText": {description: "Content warning"},
+	"scheduleConfigEntry.visibility":  {description: "Visibility overriding the account default", enum: sortedKeys(supportedVisibilities)},
+	"scheduleConfigEntry.media":       {description: "Paths of files to attach (Mastodon)"},
+
+	"muteWindowEntry.start":  {description: "Start of the window in local time (HH:MM)", required: true},
+	"muteWindowEntry.end":    {description: "End of the window in local time (HH:MM), exclusive; may be earlier than start to span midnight", required: true},
+	"muteWindowEntry.policy": {description: "skip drops posts due in the window; defer posts them when it ends (default: skip)", enum: sortedKeys(supportedMutePolicies)},
+
+	"alertsEntry.account":     {description: "Account that sends alerts, e.g. a Misskey account or a webhook (default: the .env account)"},
+	"alertsEntry.to":          {description: "Recipient mentioned in alerts, e.g. @admin or @admin@example.com"},
+	"alertsEntry.visibility":  {description: "Visibility of alert notes (default: specified, a direct message to the recipient)", enum: sortedKeys(supportedVisibilities)},
+	"alertsEntry.threshold":   {description: "Consecutive failed posts of an account before alerting (default: 1)", minimum: intPointer(1)},
+	"alertsEntry.repeatAfter": {description: "Alert again after this long while an account keeps failing, e.g. 6h (default: once until it recovers)"},
+}
+
+func ConfigJSONSchema() ([]byte, error) {
+	defs := make(map[string]*jsonSchema, len(configSchemaDefinitions))
+	root := schemaForStruct(reflect.TypeOf(scheduleConfigFile{}), defs)
+	root.Schema = configSchemaDialect
+	root.Title = "hijiki config"
+	root.Defs = defs
+	defs["account"].AllOf = accountVisibilitySchemas()
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("failed to encode config schema: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func accountVisibilitySchemas() []*jsonSchema {
+	schemas := make([]*jsonSchema, 0, len(accountVisibilities))
+	for _, accountType := range sortedKeys(accountVisibilities) {
+		condition := &jsonSchema{Properties: map[string]*jsonSchema{"type": {Enum: []string{accountType}}}}
+		if accountType != "misskey" {
+			condition.Required = []string{"type"}
+		}
+		visibility := &jsonSchema{Enum: sortedKeys(accountVisibilities[accountType])}
+		if len(visibility.Enum) == 0 {
+			visibility = &jsonSchema{Not: &jsonSchema{}}
+		}
+		schemas = append(schemas, &jsonSchema{
+			If:   condition,
+			Then: &jsonSchema{Properties: map[string]*jsonSchema{"visibility": visibility}},
+		})
+	}
+	return schemas
+}
+
+func schemaForStruct(structType reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if !field.IsExported() {
+			continue
+		}
+		name := jsonFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		property := schemaForType(field.Type, defs)
+		annotation := configSchemaFields[structType.Name()+"."+name]
+		property.Description = annotation.description
+		property.Enum = annotation.enum
+		property.Minimum = annotation.minimum
+		property.Maximum = annotation.maximum
+		if annotation.required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+func schemaForType(fieldType reflect.Type, defs map[string]*jsonSchema) *jsonSchema {
+	if name, isDefinition := configSchemaDefinitions[fieldType]; isDefinition {
+		if _, defined := defs[name]; !defined {
+			defs[name] = schemaForStruct(fieldType, defs)
+		}
+		return &jsonSchema{Ref: "#/$defs/" + name}
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Pointer:
+		return schemaForType(fieldType.Elem(), defs)
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: schemaForType(fieldType.Elem(), defs)}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: schemaForType(fieldType.Elem(), defs)}
+	case reflect.Struct:
+		return schemaForStruct(fieldType, defs)
+	default:
+		panic(fmt.Sprintf("unsupported config field type: %s", fieldType))
+	}
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func intPointer(value int) *int {
+	return &value
+}
@@ -0,0 +1,106 @@
+package infrastructure_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigJSONSchema_MatchesShippedSchema(t *testing.T) {
+	schema, err := infrastructure.ConfigJSONSchema()
+	require.NoError(t, err)
+
+	shipped, err := os.ReadFile("../../config.schema.json")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(shipped), string(schema), "config.schema.json is stale; regenerate it with `hijiki config schema > config.schema.json`")
+}
+
+func TestConfigJSONSchema_DescribesScheduleFields(t *testing.T) {
+	data, err := infrastructure.ConfigJSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Defs map[string]struct {
+			Properties           map[string]map[string]any `json:"properties"`
+			Required             []string                  `json:"required"`
+			AdditionalProperties bool                      `json:"additionalProperties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	scheduleSchema := schema.Defs["schedule"]
+	assert.Equal(t, []string{"id", "type"}, scheduleSchema.Required)
+	assert.False(t, scheduleSchema.AdditionalProperties)
+	assert.Equal(t, []any{"daily", "monthly", "weekly", "yearly"}, scheduleSchema.Properties["type"]["enum"])
+	assert.Equal(t, float64(23), scheduleSchema.Properties["hour"]["maximum"])
+	assert.Contains(t, scheduleSchema.Properties, "dayOfWeek")
+	assert.Contains(t, schema.Defs["account"].Properties, "bodyTemplate")
+}
+
+func TestConfigJSONSchema_RestrictsAccountVisibilityByType(t *testing.T) {
+	data, err := infrastructure.ConfigJSONSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Defs map[string]struct {
+			AllOf []struct {
+				If struct {
+					Properties map[string]struct {
+						Enum []string `json:"enum"`
+					} `json:"properties"`
+				} `json:"if"`
+				Then struct {
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"then"`
+			} `json:"allOf"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	visibilities := make(map[string]map[string]any)
+	for _, condition := range schema.Defs["account"].AllOf {
+		visibilities[condition.If.Properties["type"].Enum[0]] = condition.Then.Properties["visibility"]
+	}
+	assert.Equal(t, []any{"followers", "home", "public", "specified"}, visibilities["misskey"]["enum"])
+	assert.Contains(t, visibilities["mastodon"]["enum"], "unlisted")
+	assert.Contains(t, visibilities["bluesky"], "not")
+}
+
+func TestScheduleConfigLoader_Load_UnknownField_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"config.json", `{"schedules": [{"id": "weekly", "type": "weekly", "dayOfweek": 1, "hour": 9, "minute": 0, "content": "週報"}]}`},
+		{"config.yaml", "schedules:\n  - {id: weekly, type: weekly, dayOfweek: 1, hour: 9, minute: 0, content: 週報}\n"},
+		{"config.toml", "[[schedules]]\nid = \"weekly\"\ntype = \"weekly\"\ndayOfweek = 1\nhour = 9\nminute = 0\ncontent = \"週報\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.name, tt.content)
+
+			_, err := infrastructure.NewScheduleConfigLoader(path).Load()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "dayOfweek")
+		})
+	}
+}
+
+func TestScheduleConfigLoader_Load_AcceptsSchemaReference(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{
+		"$schema": "./config.schema.json",
+		"schedules": [{"id": "daily", "type": "daily", "hour": 9, "minute": 0, "content": "ひじき"}]
+	}`)
+
+	configs, err := infrastructure.NewScheduleConfigLoader(path).Load()
+
+	require.NoError(t, err)
+	assert.Len(t, configs, 1)
+}
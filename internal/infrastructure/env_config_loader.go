@@ -2,7 +2,9 @@ package infrastructure
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
 	"github.com/joho/godotenv"
@@ -17,15 +19,27 @@ func NewEnvConfigLoader(envPath string) ports.ConfigLoader {
 }
 
 func (l *EnvConfigLoader) Load() (ports.Config, error) {
-	if err := godotenv.Load(l.envPath); err != nil {
+	fileValues, err := godotenv.Read(l.envPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return ports.Config{}, err
+	}
+	getenv := func(key string) string {
+		if value, exists := os.LookupEnv(key); exists {
+			return value
+		}
+		return fileValues[key]
+	}
+
+	token, err := readSecret(getenv("MISSKEY_TOKEN"), getenv("MISSKEY_TOKEN_FILE"), "MISSKEY_TOKEN")
+	if err != nil {
 		return ports.Config{}, err
 	}
 
 	config := ports.Config{
-		MisskeyHost:  os.Getenv("MISSKEY_HOST"),
-		MisskeyToken: os.Getenv("MISSKEY_TOKEN"),
-		Visibility:   os.Getenv("MISSKEY_VISIBILITY"),
-		LocalOnly:    os.Getenv("MISSKEY_LOCAL_ONLY") == "true",
+		MisskeyHost:  getenv("MISSKEY_HOST"),
+		MisskeyToken: token,
+		Visibility:   getenv("MISSKEY_VISIBILITY"),
+		LocalOnly:    getenv("MISSKEY_LOCAL_ONLY") == "true",
 	}
 
 	if err := l.validate(config); err != nil {
@@ -44,7 +58,22 @@ func (l *EnvConfigLoader) validate(config ports.Config) error {
 		return errors.New("MISSKEY_HOST is required")
 	}
 	if config.MisskeyToken == "" {
-		return errors.New("MISSKEY_TOKEN is required")
+		return errors.New("MISSKEY_TOKEN or MISSKEY_TOKEN_FILE is required")
 	}
 	return nil
 }
+
+func readSecret(value, filePath, name string) (string, error) {
+	if filePath == "" {
+		return value, nil
+	}
+	if value != "" {
+		return "", fmt.Errorf("set either %s or %s_FILE, not both", name, name)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_FILE: %w", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
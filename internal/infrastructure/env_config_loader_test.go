@@ -0,0 +1,86 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvConfigLoader_Load_RereadsFileOnEveryLoad(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envPath, []byte("MISSKEY_HOST=old.example\nMISSKEY_TOKEN=old-token\n"), 0644))
+	loader := infrastructure.NewEnvConfigLoader(envPath)
+	_, err := loader.Load()
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(envPath, []byte("MISSKEY_HOST=new.example\nMISSKEY_TOKEN=new-token\n"), 0644))
+	config, err := loader.Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "new.example", config.MisskeyHost)
+	assert.Equal(t, "new-token", config.MisskeyToken)
+}
+
+func TestEnvConfigLoader_Load_ProcessEnvironmentTakesPrecedence(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envPath, []byte("MISSKEY_HOST=file.example\nMISSKEY_TOKEN=file-token\n"), 0644))
+	t.Setenv("MISSKEY_TOKEN", "env-token")
+
+	config, err := infrastructure.NewEnvConfigLoader(envPath).Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "file.example", config.MisskeyHost)
+	assert.Equal(t, "env-token", config.MisskeyToken)
+	assert.Equal(t, "home", config.Visibility)
+}
+
+func TestEnvConfigLoader_Load_MissingFile_UsesProcessEnvironment(t *testing.T) {
+	t.Setenv("MISSKEY_HOST", "env.example")
+	t.Setenv("MISSKEY_TOKEN", "env-token")
+
+	config, err := infrastructure.NewEnvConfigLoader(filepath.Join(t.TempDir(), ".env")).Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "env.example", config.MisskeyHost)
+	assert.Equal(t, "env-token", config.MisskeyToken)
+}
+
+func TestEnvConfigLoader_Load_ReadsTokenFromFile(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("secret-token\n"), 0600))
+	t.Setenv("MISSKEY_HOST", "env.example")
+	t.Setenv("MISSKEY_TOKEN_FILE", tokenPath)
+
+	config, err := infrastructure.NewEnvConfigLoader(filepath.Join(dir, ".env")).Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", config.MisskeyToken)
+}
+
+func TestEnvConfigLoader_Load_TokenAndTokenFile_ReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("secret-token"), 0600))
+	t.Setenv("MISSKEY_HOST", "env.example")
+	t.Setenv("MISSKEY_TOKEN", "env-token")
+	t.Setenv("MISSKEY_TOKEN_FILE", tokenPath)
+
+	_, err := infrastructure.NewEnvConfigLoader(filepath.Join(dir, ".env")).Load()
+
+	require.Error(t, err)
+}
+
+func TestEnvConfigLoader_Load_MissingTokenFile_ReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("MISSKEY_HOST", "env.example")
+	t.Setenv("MISSKEY_TOKEN_FILE", filepath.Join(dir, "missing"))
+
+	_, err := infrastructure.NewEnvConfigLoader(filepath.Join(dir, ".env")).Load()
+
+	require.Error(t, err)
+}
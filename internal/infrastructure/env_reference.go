@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"os"
+	"regexp"
+)
+
+type envLookup func(name string) (string, bool)
+
+var envReferencePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func newEnvLookup(fileValues map[string]string) envLookup {
+	return func(name string) (string, bool) {
+		if value, exists := os.LookupEnv(name); exists {
+			return value, true
+		}
+		value, exists := fileValues[name]
+		return value, exists
+	}
+}
+
+func expandEnvReferences(value string, lookup envLookup) string {
+	return envReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		value, _ := lookup(envReferencePattern.FindStringSubmatch(reference)[1])
+		return value
+	})
+}
+
+func undefinedEnvReferences(value string, lookup envLookup) []string {
+	var names []string
+	for _, match := range envReferencePattern.FindAllStringSubmatch(value, -1) {
+		if _, exists := lookup(match[1]); !exists {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
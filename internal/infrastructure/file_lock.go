@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var ErrFileLocked = errors.New("locked by another process")
+
+type FileLock struct {
+	path string
+	file *os.File
+}
+
+func LockFile(path string) (*FileLock, error) {
+	file, err := acquireFileLock(path)
+	if errors.Is(err, ErrFileLocked) {
+		if pid := readLockOwner(path); pid != 0 {
+			return nil, fmt.Errorf("%s is %w (pid %d)", path, ErrFileLocked, pid)
+		}
+		return nil, fmt.Errorf("%s is %w", path, ErrFileLocked)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	file.Truncate(0)
+	file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	return &FileLock{path: path, file: file}, nil
+}
+
+func (l *FileLock) Unlock() error {
+	return releaseFileLock(l.path, l.file)
+}
+
+func readLockOwner(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
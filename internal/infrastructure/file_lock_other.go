@@ -0,0 +1,48 @@
+//go:build !unix
+
+package infrastructure
+
+import (
+	"errors"
+	"os"
+)
+
+func acquireFileLock(path string) (*os.File, error) {
+	file, err := createLockFile(path)
+	if !errors.Is(err, ErrFileLocked) {
+		return file, err
+	}
+
+	pid := readLockOwner(path)
+	if pid == 0 || pid == os.Getpid() || processExists(pid) {
+		return nil, ErrFileLocked
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return createLockFile(path)
+}
+
+func createLockFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return nil, ErrFileLocked
+	}
+	return file, err
+}
+
+func processExists(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
+
+func releaseFileLock(path string, file *os.File) error {
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
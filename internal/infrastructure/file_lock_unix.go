@@ -0,0 +1,31 @@
+//go:build unix
+
+package infrastructure
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func acquireFileLock(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, ErrFileLocked
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func releaseFileLock(path string, file *os.File) error {
+	file.Truncate(0)
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	return file.Close()
+}
@@ -0,0 +1,88 @@
+package infrastructure
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+type FileWatcher struct {
+	paths    []string
+	interval time.Duration
+	states   map[string]fileState
+	mutex    sync.Mutex
+}
+
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func NewFileWatcher(interval time.Duration, paths ...string) *FileWatcher {
+	watcher := &FileWatcher{
+		paths:    paths,
+		interval: interval,
+		states:   make(map[string]fileState, len(paths)),
+	}
+	for _, path := range paths {
+		watcher.states[path] = statFile(path)
+	}
+	return watcher
+}
+
+func (w *FileWatcher) Watch(ctx context.Context, onChange func(changedPaths []string)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if changedPaths := w.Poll(); len(changedPaths) > 0 {
+				onChange(changedPaths)
+			}
+		}
+	}
+}
+
+func (w *FileWatcher) SetPaths(paths ...string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		if state, watched := w.states[path]; watched {
+			states[path] = state
+		} else {
+			states[path] = statFile(path)
+		}
+	}
+	w.paths = paths
+	w.states = states
+}
+
+func (w *FileWatcher) Poll() []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var changedPaths []string
+	for _, path := range w.paths {
+		current := statFile(path)
+		if current != w.states[path] {
+			w.states[path] = current
+			changedPaths = append(changedPaths, path)
+		}
+	}
+	return changedPaths
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
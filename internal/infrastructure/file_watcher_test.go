@@ -0,0 +1,60 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWatcher_Poll_WithoutChanges_ReturnsNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+	watcher := infrastructure.NewFileWatcher(time.Second, path)
+
+	assert.Empty(t, watcher.Poll())
+}
+
+func TestFileWatcher_Poll_ReportsModifiedFileOnce(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	envPath := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(configPath, []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(envPath, []byte("A=1"), 0644))
+	watcher := infrastructure.NewFileWatcher(time.Second, configPath, envPath)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"schedules": []}`), 0644))
+
+	assert.Equal(t, []string{configPath}, watcher.Poll())
+	assert.Empty(t, watcher.Poll())
+}
+
+func TestFileWatcher_Poll_ReportsCreatedAndRemovedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	watcher := infrastructure.NewFileWatcher(time.Second, path)
+
+	require.NoError(t, os.WriteFile(path, []byte("A=1"), 0644))
+	assert.Equal(t, []string{path}, watcher.Poll())
+
+	require.NoError(t, os.Remove(path))
+	assert.Equal(t, []string{path}, watcher.Poll())
+}
+
+func TestFileWatcher_SetPaths_StartsWatchingNewPathsFromCurrentState(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	fragmentPath := filepath.Join(dir, "fragment.json")
+	require.NoError(t, os.WriteFile(configPath, []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(fragmentPath, []byte("{}"), 0644))
+	watcher := infrastructure.NewFileWatcher(time.Second, configPath)
+
+	watcher.SetPaths(configPath, fragmentPath)
+	assert.Empty(t, watcher.Poll())
+
+	require.NoError(t, os.WriteFile(fragmentPath, []byte(`{"schedules": []}`), 0644))
+	assert.Equal(t, []string{fragmentPath}, watcher.Poll())
+}
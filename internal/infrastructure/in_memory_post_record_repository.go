@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"sync"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type InMemoryPostRecordRepository struct {
+	base    ports.PostRecordRepository
+	records map[string]domain.PostRecord
+	mutex   sync.RWMutex
+}
+
+func NewInMemoryPostRecordRepository(base ports.PostRecordRepository) ports.PostRecordRepository {
+	return &InMemoryPostRecordRepository{
+		base:    base,
+		records: make(map[string]domain.PostRecord),
+	}
+}
+
+func (r *InMemoryPostRecordRepository) Find(scheduleID string) (domain.PostRecord, error) {
+	r.mutex.RLock()
+	record, exists := r.records[scheduleID]
+	r.mutex.RUnlock()
+	if exists {
+		return record, nil
+	}
+
+	if r.base == nil {
+		return domain.PostRecord{}, nil
+	}
+	return r.base.Find(scheduleID)
+}
+
+func (r *InMemoryPostRecordRepository) Save(record domain.PostRecord) error {
+	if record.Failed() {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records[record.ScheduleID] = record
+	return nil
+}
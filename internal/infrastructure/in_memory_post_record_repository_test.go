@@ -0,0 +1,39 @@
+package infrastructure_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryPostRecordRepository_Find_FallsBackToBaseRepository(t *testing.T) {
+	base := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, base.Save(domain.NewPostRecord("daily", postedAt)))
+	repository := infrastructure.NewInMemoryPostRecordRepository(base)
+
+	record, err := repository.Find("daily")
+
+	require.NoError(t, err)
+	assert.True(t, postedAt.Equal(record.LastPostedAt))
+}
+
+func TestInMemoryPostRecordRepository_Save_DoesNotWriteToBaseRepository(t *testing.T) {
+	base := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	repository := infrastructure.NewInMemoryPostRecordRepository(base)
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", postedAt)))
+
+	record, err := repository.Find("daily")
+	require.NoError(t, err)
+	assert.Equal(t, postedAt, record.LastPostedAt)
+	baseRecord, err := base.Find("daily")
+	require.NoError(t, err)
+	assert.True(t, baseRecord.IsZero())
+}
@@ -0,0 +1,60 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type JSONPauseStore struct {
+	filePath string
+	mutex    sync.Mutex
+}
+
+type jsonPauseState struct {
+	All       bool     `json:"all"`
+	Schedules []string `json:"schedules"`
+}
+
+func NewJSONPauseStore(filePath string) ports.PauseStore {
+	return &JSONPauseStore{filePath: filePath}
+}
+
+func (s *JSONPauseStore) Load() (domain.PauseState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		return domain.PauseState{}, nil
+	}
+	if err != nil {
+		return domain.PauseState{}, err
+	}
+
+	var stored jsonPauseState
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return domain.PauseState{}, fmt.Errorf("failed to parse %s: %w", s.filePath, err)
+	}
+
+	state := domain.PauseState{All: stored.All, Schedules: make(map[string]bool, len(stored.Schedules))}
+	for _, id := range stored.Schedules {
+		state.Schedules[id] = true
+	}
+	return state, nil
+}
+
+func (s *JSONPauseStore) Save(state domain.PauseState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := json.MarshalIndent(jsonPauseState{All: state.All, Schedules: state.PausedSchedules()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.filePath, data, "")
+}
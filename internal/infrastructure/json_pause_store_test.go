@@ -0,0 +1,40 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPauseStore_Load_MissingFile_ReturnsNothingPaused(t *testing.T) {
+	store := infrastructure.NewJSONPauseStore(filepath.Join(t.TempDir(), "pause.json"))
+
+	state, err := store.Load()
+
+	require.NoError(t, err)
+	assert.False(t, state.IsPaused("daily"))
+}
+
+func TestJSONPauseStore_Save_PersistsScheduleAndGlobalPauses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pause.json")
+	store := infrastructure.NewJSONPauseStore(path)
+
+	require.NoError(t, store.Save(domain.PauseState{}.WithSchedule("weekly", true).WithSchedule("daily", true)))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"all": false, "schedules": ["daily", "weekly"]}`, string(data))
+
+	state, err := infrastructure.NewJSONPauseStore(path).Load()
+	require.NoError(t, err)
+	require.NoError(t, store.Save(state.WithSchedule("daily", false).WithAll(true)))
+
+	state, err = store.Load()
+	require.NoError(t, err)
+	assert.True(t, state.All)
+	assert.Equal(t, []string{"weekly"}, state.PausedSchedules())
+}
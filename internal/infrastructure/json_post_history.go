@@ -0,0 +1,208 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type jsonHistoryEntry struct {
+	ScheduleID  string            `json:"schedule_id"`
+	Account     string            `json:"account,omitempty"`
+	AttemptedAt time.Time         `json:"attempted_at"`
+	ScheduledAt *time.Time        `json:"scheduled_at,omitempty"`
+	Status      domain.PostStatus `json:"status"`
+	LatencyMS   int64             `json:"latency_ms"`
+	TextHash    string            `json:"text_hash,omitempty"`
+	NoteID      string            `json:"note_id,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+func newJSONHistoryEntry(record domain.PostRecord) jsonHistoryEntry {
+	status := record.Status
+	if status == "" {
+		status = domain.PostStatusPosted
+	}
+	entry := jsonHistoryEntry{
+		ScheduleID:  record.ScheduleID,
+		Account:     record.Account,
+		AttemptedAt: record.LastPostedAt,
+		Status:      status,
+		LatencyMS:   record.Latency.Milliseconds(),
+		TextHash:    record.TextHash,
+		NoteID:      record.NoteID,
+		Error:       record.Error,
+	}
+	if !record.ScheduledAt.IsZero() {
+		entry.ScheduledAt = &record.ScheduledAt
+	}
+	return entry
+}
+
+func (e jsonHistoryEntry) record() domain.PostRecord {
+	record := domain.PostRecord{
+		ScheduleID:   e.ScheduleID,
+		Account:      e.Account,
+		LastPostedAt: e.AttemptedAt,
+		NoteID:       e.NoteID,
+		Status:       e.Status,
+		Error:        e.Error,
+		Latency:      time.Duration(e.LatencyMS) * time.Millisecond,
+		TextHash:     e.TextHash,
+	}
+	if e.ScheduledAt != nil {
+		record.ScheduledAt = *e.ScheduledAt
+	}
+	return record
+}
+
+func (r *JSONPostRecordRepository) HistoryPath() string {
+	return strings.TrimSuffix(r.filePath, filepath.Ext(r.filePath)) + ".history.jsonl"
+}
+
+func (r *JSONPostRecordRepository) Append(record domain.PostRecord) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.readOnly {
+		return ErrReadOnlyRecords
+	}
+	return r.appendHistory(record)
+}
+
+func (r *JSONPostRecordRepository) appendHistory(record domain.PostRecord) error {
+	line, err := json.Marshal(newJSONHistoryEntry(record))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.HistoryPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open post history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append post history: %w", err)
+	}
+	return file.Sync()
+}
+
+func (r *JSONPostRecordRepository) Query(query ports.PostHistoryQuery) ([]domain.PostRecord, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, _, err := r.readHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []domain.PostRecord
+	for _, entry := range entries {
+		if query.ScheduleID != "" && entry.ScheduleID != query.ScheduleID {
+			continue
+		}
+		if !query.Since.IsZero() && entry.AttemptedAt.Before(query.Since) {
+			continue
+		}
+		if query.Status != "" && entry.Status != query.Status {
+			continue
+		}
+		records = append(records, entry.record())
+	}
+
+	if query.Limit > 0 && len(records) > query.Limit {
+		records = records[len(records)-query.Limit:]
+	}
+	return records, nil
+}
+
+func (r *JSONPostRecordRepository) Prune(before time.Time) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.readOnly {
+		return 0, ErrReadOnlyRecords
+	}
+
+	entries, unreadable, err := r.readHistory()
+	if err != nil {
+		return 0, err
+	}
+
+	var buffer bytes.Buffer
+	for _, line := range unreadable {
+		buffer.Write(append(line, '\n'))
+	}
+	kept := 0
+	for _, entry := range entries {
+		if entry.AttemptedAt.Before(before) {
+			continue
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return 0, err
+		}
+		buffer.Write(append(line, '\n'))
+		kept++
+	}
+
+	pruned := len(entries) - kept
+	if pruned == 0 {
+		return 0, nil
+	}
+	if err := writeFileAtomic(r.HistoryPath(), buffer.Bytes(), ""); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+func (r *JSONPostRecordRepository) readHistory() ([]jsonHistoryEntry, [][]byte, error) {
+	file, err := os.Open(r.HistoryPath())
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open post history: %w", err)
+	}
+	defer file.Close()
+
+	var entries []jsonHistoryEntry
+	var unreadable [][]byte
+	firstUnreadable := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry jsonHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			if len(unreadable) == 0 {
+				firstUnreadable = lineNumber
+			}
+			unreadable = append(unreadable, bytes.Clone(scanner.Bytes()))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read post history: %w", err)
+	}
+
+	if len(unreadable) != r.unreadableLogged {
+		r.unreadableLogged = len(unreadable)
+		if len(unreadable) > 0 {
+			slog.Warn("Skipped unreadable post history lines", "path", r.HistoryPath(), "count", len(unreadable), "first_line", firstUnreadable)
+		}
+	}
+	return entries, unreadable, nil
+}
@@ -2,7 +2,10 @@ package infrastructure
 
 import (
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -11,8 +14,10 @@ import (
 )
 
 type JSONPostRecordRepository struct {
-	filePath string
-	mutex    sync.RWMutex
+	filePath         string
+	readOnly         bool
+	unreadableLogged int
+	mutex            sync.Mutex
 }
 
 type jsonRecordStore struct {
@@ -20,17 +25,22 @@ type jsonRecordStore struct {
 }
 
 type jsonRecord struct {
-	ScheduleID   string    `json:"schedule_id"`
-	LastPostedAt time.Time `json:"last_posted_at"`
+	ScheduleID   string     `json:"schedule_id"`
+	LastPostedAt time.Time  `json:"last_posted_at"`
+	ScheduledAt  *time.Time `json:"scheduled_at,omitempty"`
 }
 
 func NewJSONPostRecordRepository(filePath string) ports.PostRecordRepository {
 	return &JSONPostRecordRepository{filePath: filePath}
 }
 
+func NewReadOnlyJSONPostRecordRepository(filePath string) ports.PostRecordRepository {
+	return &JSONPostRecordRepository{filePath: filePath, readOnly: true}
+}
+
 func (r *JSONPostRecordRepository) Find(scheduleID string) (domain.PostRecord, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
 	store, err := r.loadStore()
 	if err != nil {
@@ -45,13 +55,24 @@ func (r *JSONPostRecordRepository) Find(scheduleID string) (domain.PostRecord, e
 		return domain.PostRecord{}, nil
 	}
 
-	return domain.NewPostRecord(record.ScheduleID, record.LastPostedAt), nil
+	postRecord := domain.NewPostRecord(record.ScheduleID, record.LastPostedAt)
+	if record.ScheduledAt != nil {
+		postRecord.ScheduledAt = *record.ScheduledAt
+	}
+	return postRecord, nil
 }
 
 func (r *JSONPostRecordRepository) Save(record domain.PostRecord) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	if r.readOnly {
+		return ErrReadOnlyRecords
+	}
+	if record.Failed() {
+		return r.appendHistory(record)
+	}
+
 	store, err := r.loadStore()
 	if err != nil && !os.IsNotExist(err) {
 		return err
@@ -60,27 +81,62 @@ func (r *JSONPostRecordRepository) Save(record domain.PostRecord) error {
 		store.Records = make(map[string]jsonRecord)
 	}
 
-	store.Records[record.ScheduleID] = jsonRecord{
+	stored := jsonRecord{
 		ScheduleID:   record.ScheduleID,
 		LastPostedAt: record.LastPostedAt,
 	}
+	if !record.ScheduledAt.IsZero() {
+		stored.ScheduledAt = &record.ScheduledAt
+	}
+	store.Records[record.ScheduleID] = stored
 
-	return r.saveStore(store)
+	if err := r.saveStore(store); err != nil {
+		return err
+	}
+	if err := r.appendHistory(record); err != nil {
+		slog.Warn("Failed to append post history; the post record was saved", "path", r.HistoryPath(), "schedule_id", record.ScheduleID, "error", err)
+	}
+	return nil
+}
+
+func (r *JSONPostRecordRepository) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	syncDir(filepath.Dir(r.filePath))
+	return nil
 }
 
 func (r *JSONPostRecordRepository) loadStore() (jsonRecordStore, error) {
-	var store jsonRecordStore
+	store, err := readJSONRecordStore(r.filePath)
+	if err == nil || (os.IsNotExist(err) && !fileExists(r.backupPath())) {
+		return store, err
+	}
 
-	data, err := os.ReadFile(r.filePath)
-	if err != nil {
-		return jsonRecordStore{Records: make(map[string]jsonRecord)}, err
+	backup, backupErr := readJSONRecordStore(r.backupPath())
+	if r.readOnly {
+		if backupErr != nil {
+			slog.Warn("Post records are unreadable and no usable backup exists; reading them as empty", "path", r.filePath, "error", err)
+		} else if !os.IsNotExist(err) {
+			slog.Warn("Post records are unreadable; reading the backup without repairing", "path", r.filePath, "backup", r.backupPath(), "error", err)
+		}
+		return backup, nil
+	}
+	if backupErr != nil {
+		backup = jsonRecordStore{Records: make(map[string]jsonRecord)}
+		slog.Error("Post records are unreadable and no usable backup exists; starting with empty records", "path", r.filePath, "error", err)
+	} else {
+		slog.Warn("Post records are unreadable; recovered from the backup", "path", r.filePath, "backup", r.backupPath(), "error", err)
 	}
 
-	if err := json.Unmarshal(data, &store); err != nil {
-		return jsonRecordStore{Records: make(map[string]jsonRecord)}, err
+	if !os.IsNotExist(err) {
+		corruptPath := fmt.Sprintf("%s.corrupt-%s", r.filePath, time.Now().Format("20060102T150405"))
+		if renameErr := os.Rename(r.filePath, corruptPath); renameErr != nil {
+			return backup, fmt.Errorf("failed to move aside corrupt post records: %w", renameErr)
+		}
+		slog.Warn("Moved the unreadable post records aside", "path", corruptPath)
 	}
 
-	return store, nil
+	return backup, nil
 }
 
 func (r *JSONPostRecordRepository) saveStore(store jsonRecordStore) error {
@@ -89,5 +145,75 @@ func (r *JSONPostRecordRepository) saveStore(store jsonRecordStore) error {
 		return err
 	}
 
-	return os.WriteFile(r.filePath, data, 0644)
+	return writeFileAtomic(r.filePath, data, r.backupPath())
+}
+
+func (r *JSONPostRecordRepository) backupPath() string {
+	return r.filePath + ".bak"
+}
+
+func writeFileAtomic(path string, data []byte, backupPath string) error {
+	dir := filepath.Dir(path)
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to sync %s: %w", path, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if backupPath != "" {
+		if err := os.Rename(path, backupPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	syncDir(dir)
+	return nil
+}
+
+func readJSONRecordStore(path string) (jsonRecordStore, error) {
+	store := jsonRecordStore{Records: make(map[string]jsonRecord)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return store, err
+	}
+
+	if err := json.Unmarshal(data, &store); err != nil {
+		return jsonRecordStore{Records: make(map[string]jsonRecord)}, err
+	}
+	if store.Records == nil {
+		store.Records = make(map[string]jsonRecord)
+	}
+
+	return store, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func syncDir(dir string) {
+	if file, err := os.Open(dir); err == nil {
+		file.Sync()
+		file.Close()
+	}
 }
@@ -0,0 +1,243 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPostRecordRepository_Save_KeepsPreviousGenerationAsBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	first := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	second := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", first)))
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", second)))
+
+	backup, err := infrastructure.NewJSONPostRecordRepository(path + ".bak").Find("daily")
+	require.NoError(t, err)
+	assert.True(t, first.Equal(backup.LastPostedAt))
+	tempFiles, err := filepath.Glob(filepath.Join(dir, ".*.tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tempFiles)
+}
+
+func TestJSONPostRecordRepository_Find_CorruptFile_RecoversFromBackup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", postedAt)))
+	require.NoError(t, repository.Save(domain.NewPostRecord("weekly", postedAt)))
+	require.NoError(t, os.WriteFile(path, []byte(`{"records": {"daily": {"schedule_id": "da`), 0644))
+
+	record, err := repository.Find("daily")
+
+	require.NoError(t, err)
+	assert.True(t, postedAt.Equal(record.LastPostedAt))
+	corruptFiles, err := filepath.Glob(path + ".corrupt-*")
+	require.NoError(t, err)
+	assert.Len(t, corruptFiles, 1)
+}
+
+func TestReadOnlyJSONPostRecordRepository_Find_CorruptFile_LeavesFilesUntouched(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post_records.json")
+	writable := infrastructure.NewJSONPostRecordRepository(path)
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, writable.Save(domain.NewPostRecord("daily", postedAt)))
+	require.NoError(t, writable.Save(domain.NewPostRecord("weekly", postedAt)))
+	corrupt := []byte(`{"records": {"daily": {"schedule_id": "da`)
+	require.NoError(t, os.WriteFile(path, corrupt, 0644))
+	before, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	repository := infrastructure.NewReadOnlyJSONPostRecordRepository(path)
+
+	record, err := repository.Find("daily")
+
+	require.NoError(t, err)
+	assert.True(t, postedAt.Equal(record.LastPostedAt))
+	assert.ErrorIs(t, repository.Save(domain.NewPostRecord("daily", postedAt)), infrastructure.ErrReadOnlyRecords)
+	after, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, len(before), len(after))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, corrupt, data)
+}
+
+func TestJSONPostRecordRepository_Find_CorruptFileWithoutBackup_StartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+
+	record, err := repository.Find("daily")
+	require.NoError(t, err)
+	assert.True(t, record.IsZero())
+
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))))
+	record, err = repository.Find("daily")
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+}
+
+func TestJSONPostRecordRepository_Find_MissingFileAfterCrash_RecoversFromBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))))
+	require.NoError(t, os.Rename(path, path+".bak"))
+
+	record, err := repository.Find("daily")
+
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+}
+
+func TestJSONPostRecordRepository_Save_IgnoresFailedAttempts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("daily", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC), errors.New("timeout"))))
+
+	record, err := repository.Find("daily")
+	require.NoError(t, err)
+	assert.True(t, record.IsZero())
+}
+
+func TestJSONPostRecordRepository_Save_HistoryError_StillSavesRecord(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	require.NoError(t, os.Mkdir(repository.(*infrastructure.JSONPostRecordRepository).HistoryPath(), 0755))
+	postedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", postedAt)))
+
+	record, err := repository.Find("daily")
+	require.NoError(t, err)
+	assert.True(t, postedAt.Equal(record.LastPostedAt))
+}
+
+func TestLockFile_SecondLockFails_UntilUnlocked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json.lock")
+	lock, err := infrastructure.LockFile(path)
+	require.NoError(t, err)
+
+	_, err = infrastructure.LockFile(path)
+	require.ErrorIs(t, err, infrastructure.ErrFileLocked)
+	assert.Contains(t, err.Error(), "pid")
+
+	require.NoError(t, lock.Unlock())
+	lock, err = infrastructure.LockFile(path)
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestLockFile_LeftOverLockOfExitedProcess_IsTakenOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json.lock")
+	require.NoError(t, os.WriteFile(path, []byte("2147483646\n"), 0644))
+
+	lock, err := infrastructure.LockFile(path)
+
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestJSONPostRecordRepository_Query_FiltersHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	posted := domain.NewPostRecord("daily", time.Date(2026, 2, 1, 12, 37, 0, 0, time.UTC))
+	posted.NoteID = "note-1"
+	posted.Latency = 250 * time.Millisecond
+	posted.TextHash = domain.NewTextPost("ひじき").TextHash()
+	failed := domain.NewFailedPostRecord("daily", time.Date(2026, 2, 2, 12, 37, 0, 0, time.UTC), errors.New("timeout"))
+	other := domain.NewPostRecord("weekly", time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC))
+	for _, record := range []domain.PostRecord{posted, failed, other} {
+		require.NoError(t, repository.Save(record))
+	}
+	history := repository.(ports.PostHistory)
+
+	all, err := history.Query(ports.PostHistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "note-1", all[0].NoteID)
+	assert.Equal(t, 250*time.Millisecond, all[0].Latency)
+	assert.Equal(t, posted.TextHash, all[0].TextHash)
+
+	failures, err := history.Query(ports.PostHistoryQuery{ScheduleID: "daily", Status: domain.PostStatusFailed})
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "timeout", failures[0].Error)
+
+	recent, err := history.Query(ports.PostHistoryQuery{Since: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "weekly", recent[0].ScheduleID)
+}
+
+func TestJSONPostRecordRepository_Prune_RemovesOldHistoryOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))))
+	require.NoError(t, repository.Save(domain.NewPostRecord("weekly", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))))
+	history := repository.(ports.PostHistory)
+
+	pruned, err := history.Prune(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	remaining, err := history.Query(ports.PostHistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "weekly", remaining[0].ScheduleID)
+	record, err := repository.Find("daily")
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+}
+
+func TestJSONPostRecordRepository_History_SkipsUnreadableLinesButKeepsThemOnPrune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post_records.json")
+	repository := infrastructure.NewJSONPostRecordRepository(path)
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))))
+	historyPath := repository.(*infrastructure.JSONPostRecordRepository).HistoryPath()
+	file, err := os.OpenFile(historyPath, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString("{\"schedule_id\": \"week\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, repository.Save(domain.NewPostRecord("weekly", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))))
+	history := repository.(ports.PostHistory)
+
+	records, err := history.Query(ports.PostHistoryQuery{})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	pruned, err := history.Prune(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	data, err := os.ReadFile(historyPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "{\"schedule_id\": \"week\n")
+}
+
+func TestJSONPostRecordRepository_Find_KeepsScheduledTimeOfDeferredPost(t *testing.T) {
+	repository := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	record := domain.NewPostRecord("nightly", time.Date(2026, 2, 2, 6, 0, 0, 0, time.UTC))
+	record.ScheduledAt = time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)
+	require.NoError(t, repository.Save(record))
+
+	found, err := repository.Find("nightly")
+
+	require.NoError(t, err)
+	assert.True(t, record.ScheduledAt.Equal(found.ScheduledAt))
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type LogOptions struct {
+	Format     string
+	Level      string
+	Output     string
+	FilePath   string
+	MaxSize    int64
+	MaxBackups int
+}
+
+func NewLogger(options LogOptions) (*slog.Logger, io.Closer, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(options.Level)); err != nil {
+		return nil, nil, fmt.Errorf("invalid log level: %s (expected debug, info, warn or error)", options.Level)
+	}
+
+	var writer io.Writer
+	var closer io.Closer = io.NopCloser(nil)
+	switch options.Output {
+	case "stderr":
+		writer = os.Stderr
+	case "file", "both":
+		file, err := OpenRotatingFile(options.FilePath, options.MaxSize, options.MaxBackups)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		writer, closer = file, file
+		if options.Output == "both" {
+			writer = io.MultiWriter(os.Stderr, file)
+		}
+	default:
+		return nil, nil, fmt.Errorf("invalid log output: %s (expected stderr, file or both)", options.Output)
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(options.Format) {
+	case "text":
+		return slog.New(slog.NewTextHandler(writer, handlerOptions)), closer, nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(writer, handlerOptions)), closer, nil
+	default:
+		closer.Close()
+		return nil, nil, fmt.Errorf("invalid log format: %s (expected text or json)", options.Format)
+	}
+}
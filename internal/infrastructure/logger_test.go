@@ -0,0 +1,42 @@
+package infrastructure_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_WritesJSONWithAttributesAboveLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hijiki.log")
+	logger, closer, err := infrastructure.NewLogger(infrastructure.LogOptions{Format: "json", Level: "warn", Output: "file", FilePath: path})
+	require.NoError(t, err)
+
+	logger.Info("Posted", "schedule_id", "noon")
+	logger.Error("Post failed", "schedule_id", "noon", "attempt", 2)
+	require.NoError(t, closer.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "Post failed", entry["msg"])
+	assert.Equal(t, "noon", entry["schedule_id"])
+	assert.Equal(t, float64(2), entry["attempt"])
+}
+
+func TestNewLogger_RejectsInvalidOptions(t *testing.T) {
+	_, _, err := infrastructure.NewLogger(infrastructure.LogOptions{Format: "xml", Level: "info", Output: "stderr"})
+	assert.Error(t, err)
+
+	_, _, err = infrastructure.NewLogger(infrastructure.LogOptions{Format: "text", Level: "loud", Output: "stderr"})
+	assert.Error(t, err)
+
+	_, _, err = infrastructure.NewLogger(infrastructure.LogOptions{Format: "text", Level: "info", Output: "syslog"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type MastodonConfig struct {
+	Host       string
+	Token      string
+	Visibility string
+}
+
+type MastodonPoster struct {
+	host       string
+	token      string
+	visibility string
+	httpClient *http.Client
+}
+
+type mastodonStatusRequest struct {
+	Status      string   `json:"status"`
+	SpoilerText string   `json:"spoiler_text,omitempty"`
+	Visibility  string   `json:"visibility"`
+	MediaIDs    []string `json:"media_ids,omitempty"`
+}
+
+type mastodonStatusResponse struct {
+	ID string `json:"id"`
+}
+
+type mastodonMediaResponse struct {
+	ID  string  `json:"id"`
+	URL *string `json:"url"`
+}
+
+const (
+	mastodonMediaPollInterval    = 200 * time.Millisecond
+	mastodonMediaMaxPollInterval = 2 * time.Second
+)
+
+func NewMastodonPoster(config MastodonConfig) ports.Poster {
+	return &MastodonPoster{
+		host:       config.Host,
+		token:      config.Token,
+		visibility: config.Visibility,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *MastodonPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	mediaIDs := make([]string, 0, len(post.MediaPaths))
+	for _, mediaPath := range post.MediaPaths {
+		mediaID, err := p.uploadMedia(ctx, mediaPath)
+		if err != nil {
+			return domain.PostResult{}, err
+		}
+		mediaIDs = append(mediaIDs, mediaID)
+	}
+
+	request := mastodonStatusRequest{
+		Status:      post.Text,
+		SpoilerText: post.SpoilerText,
+		Visibility:  mastodonVisibility(defaultString(post.Visibility, p.visibility)),
+		MediaIDs:    mediaIDs,
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/statuses", baseURL(p.host))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	if post.IdempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", post.IdempotencyKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.PostResult{}, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+	}
+
+	var status mastodonStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to decode status response: %w", err)
+	}
+	return domain.PostResult{ID: status.ID}, nil
+}
+
+func (p *MastodonPoster) uploadMedia(ctx context.Context, mediaPath string) (string, error) {
+	file, err := os.Open(mediaPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open media file: %w", err)
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(mediaPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to create media form: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return "", fmt.Errorf("failed to read media file: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to create media form: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v2/media", baseURL(p.host))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("media upload failed with status code: %d", resp.StatusCode)
+	}
+
+	var media mastodonMediaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&media); err != nil {
+		return "", fmt.Errorf("failed to decode media response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusAccepted && media.URL == nil {
+		if err := p.waitForMedia(ctx, media.ID); err != nil {
+			return "", err
+		}
+	}
+	return media.ID, nil
+}
+
+func (p *MastodonPoster) waitForMedia(ctx context.Context, mediaID string) error {
+	interval := mastodonMediaPollInterval
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("media %s was not processed in time: %w", mediaID, ctx.Err())
+		case <-timer.C:
+		}
+
+		processed, err := p.mediaProcessed(ctx, mediaID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			return nil
+		}
+		interval = min(interval*2, mastodonMediaMaxPollInterval)
+	}
+}
+
+func (p *MastodonPoster) mediaProcessed(ctx context.Context, mediaID string) (bool, error) {
+	url := fmt.Sprintf("%s/api/v1/media/%s", baseURL(p.host), mediaID)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to check media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return false, nil
+	case http.StatusOK:
+	default:
+		return false, fmt.Errorf("media check failed with status code: %d", resp.StatusCode)
+	}
+
+	var media mastodonMediaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&media); err != nil {
+		return false, fmt.Errorf("failed to decode media response: %w", err)
+	}
+	return media.URL != nil, nil
+}
+
+func mastodonVisibility(visibility string) string {
+	switch visibility {
+	case "home":
+		return "unlisted"
+	case "followers":
+		return "private"
+	case "specified":
+		return "direct"
+	default:
+		return visibility
+	}
+}
@@ -0,0 +1,183 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mastodonStandIn struct {
+	server         *httptest.Server
+	statusRequests []map[string]any
+	headers        []http.Header
+	uploadedFiles  []string
+	statusCode     int
+	statusBody     string
+	processing     int
+	mediaChecks    int
+}
+
+func newMastodonStandIn(t *testing.T) *mastodonStandIn {
+	t.Helper()
+	standIn := &mastodonStandIn{statusCode: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/media", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		standIn.uploadedFiles = append(standIn.uploadedFiles, header.Filename+":"+string(data))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]any{"id": "media-1", "url": nil})
+	})
+	mux.HandleFunc("/api/v1/media/media-1", func(w http.ResponseWriter, r *http.Request) {
+		standIn.mediaChecks++
+		if standIn.mediaChecks <= standIn.processing {
+			w.WriteHeader(http.StatusPartialContent)
+			json.NewEncoder(w).Encode(map[string]any{"id": "media-1", "url": nil})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "media-1", "url": "https://files.example/media-1.png"})
+	})
+	mux.HandleFunc("/api/v1/statuses", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		standIn.statusRequests = append(standIn.statusRequests, body)
+		standIn.headers = append(standIn.headers, r.Header.Clone())
+		w.WriteHeader(standIn.statusCode)
+		if standIn.statusBody != "" {
+			io.WriteString(w, standIn.statusBody)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "status-1"})
+	})
+	standIn.server = httptest.NewServer(mux)
+	t.Cleanup(standIn.server.Close)
+	return standIn
+}
+
+func TestMastodonPoster_Post_SendsStatusWithMappedVisibility(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{
+		Host:       standIn.server.URL,
+		Token:      "mastodon-token",
+		Visibility: "home",
+	})
+
+	result, err := poster.Post(context.Background(), domain.Post{Text: "おひ", SpoilerText: "ネタバレ", IdempotencyKey: "daily-ohi:2026-02-01"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "status-1", result.ID)
+	require.Len(t, standIn.statusRequests, 1)
+	assert.Equal(t, "おひ", standIn.statusRequests[0]["status"])
+	assert.Equal(t, "ネタバレ", standIn.statusRequests[0]["spoiler_text"])
+	assert.Equal(t, "unlisted", standIn.statusRequests[0]["visibility"])
+	assert.Equal(t, "Bearer mastodon-token", standIn.headers[0].Get("Authorization"))
+	assert.Equal(t, "daily-ohi:2026-02-01", standIn.headers[0].Get("Idempotency-Key"))
+}
+
+func TestMastodonPoster_Post_PostVisibilityOverridesAccountDefault(t *testing.T) {
+	tests := map[string]string{
+		"public":    "public",
+		"followers": "private",
+		"specified": "direct",
+		"unlisted":  "unlisted",
+	}
+	for visibility, expected := range tests {
+		t.Run(visibility, func(t *testing.T) {
+			standIn := newMastodonStandIn(t)
+			poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL, Visibility: "home"})
+
+			_, err := poster.Post(context.Background(), domain.Post{Text: "test", Visibility: visibility})
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, standIn.statusRequests[0]["visibility"])
+		})
+	}
+}
+
+func TestMastodonPoster_Post_UploadsMediaBeforeStatus(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	mediaPath := filepath.Join(t.TempDir(), "hijiki.png")
+	require.NoError(t, os.WriteFile(mediaPath, []byte("image-bytes"), 0644))
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL, Visibility: "public"})
+
+	_, err := poster.Post(context.Background(), domain.Post{Text: "ひじき", MediaPaths: []string{mediaPath}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hijiki.png:image-bytes"}, standIn.uploadedFiles)
+	assert.Equal(t, []any{"media-1"}, standIn.statusRequests[0]["media_ids"])
+}
+
+func TestMastodonPoster_Post_WaitsUntilAcceptedMediaIsProcessed(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	standIn.processing = 1
+	mediaPath := filepath.Join(t.TempDir(), "hijiki.png")
+	require.NoError(t, os.WriteFile(mediaPath, []byte("image-bytes"), 0644))
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL, Visibility: "public"})
+
+	_, err := poster.Post(context.Background(), domain.Post{Text: "ひじき", MediaPaths: []string{mediaPath}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, standIn.mediaChecks)
+	require.Len(t, standIn.statusRequests, 1)
+	assert.Equal(t, []any{"media-1"}, standIn.statusRequests[0]["media_ids"])
+}
+
+func TestMastodonPoster_Post_WhenMediaIsStillProcessingAtDeadline_ReturnsErrorWithoutPosting(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	standIn.processing = 1000
+	mediaPath := filepath.Join(t.TempDir(), "hijiki.png")
+	require.NoError(t, os.WriteFile(mediaPath, []byte("image-bytes"), 0644))
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL})
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, err := poster.Post(ctx, domain.Post{Text: "ひじき", MediaPaths: []string{mediaPath}})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, standIn.statusRequests)
+}
+
+func TestMastodonPoster_Post_MissingMediaFile_ReturnsErrorWithoutPosting(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL})
+
+	_, err := poster.Post(context.Background(), domain.Post{Text: "test", MediaPaths: []string{filepath.Join(t.TempDir(), "missing.png")}})
+
+	require.Error(t, err)
+	assert.Empty(t, standIn.statusRequests)
+}
+
+func TestMastodonPoster_Post_WhenServerRejects_ReturnsError(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	standIn.statusCode = http.StatusUnprocessableEntity
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL})
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("test"))
+
+	require.Error(t, err)
+}
+
+func TestMastodonPoster_Post_InvalidResponse_ReturnsError(t *testing.T) {
+	standIn := newMastodonStandIn(t)
+	standIn.statusBody = "<html>maintenance</html>"
+	poster := infrastructure.NewMastodonPoster(infrastructure.MastodonConfig{Host: standIn.server.URL})
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("test"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode status response")
+}
@@ -0,0 +1,157 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+var ErrCredentialsRejected = errors.New("credentials were rejected")
+
+type MisskeyAccount struct {
+	Username          string
+	Version           string
+	MaxNoteTextLength int
+	MaxCwLength       int
+	CanPublicNote     bool
+}
+
+type MisskeyInspector struct {
+	host       string
+	token      string
+	httpClient *http.Client
+}
+
+type misskeyMeResponse struct {
+	Username string `json:"username"`
+	Policies struct {
+		CanPublicNote *bool `json:"canPublicNote"`
+	} `json:"policies"`
+}
+
+type misskeyErrorResponse struct {
+	Error struct {
+		Code string `json:"code"`
+	} `json:"error"`
+}
+
+type misskeyMetaResponse struct {
+	Version           string `json:"version"`
+	MaxNoteTextLength int    `json:"maxNoteTextLength"`
+	MaxCwLength       int    `json:"maxCwLength"`
+}
+
+func NewMisskeyInspector(host, token string) *MisskeyInspector {
+	return &MisskeyInspector{
+		host:       host,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (i *MisskeyInspector) Inspect(ctx context.Context) (MisskeyAccount, error) {
+	var me misskeyMeResponse
+	if err := i.call(ctx, "i", map[string]string{"i": i.token}, &me); err != nil {
+		return MisskeyAccount{}, err
+	}
+	if err := i.checkNotePermission(ctx); err != nil {
+		return MisskeyAccount{}, err
+	}
+	var meta misskeyMetaResponse
+	if err := i.call(ctx, "meta", map[string]bool{"detail": false}, &meta); err != nil {
+		return MisskeyAccount{}, err
+	}
+
+	account := MisskeyAccount{
+		Username:          me.Username,
+		Version:           meta.Version,
+		MaxNoteTextLength: meta.MaxNoteTextLength,
+		MaxCwLength:       meta.MaxCwLength,
+		CanPublicNote:     me.Policies.CanPublicNote == nil || *me.Policies.CanPublicNote,
+	}
+	return account, nil
+}
+
+func (i *MisskeyInspector) checkNotePermission(ctx context.Context) error {
+	body, err := json.Marshal(map[string]string{"i": i.token, "visibility": "hijiki-permission-check"})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/notes/create", baseURL(i.host))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", baseURL(i.host), err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusBadRequest:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		var response misskeyErrorResponse
+		json.NewDecoder(resp.Body).Decode(&response)
+		return fmt.Errorf("/api/notes/create: %w: the token lacks the write:notes permission (status code %d, %s)", ErrCredentialsRejected, resp.StatusCode, defaultString(response.Error.Code, "no error code"))
+	default:
+		return fmt.Errorf("/api/notes/create failed with status code: %d", resp.StatusCode)
+	}
+}
+
+func (i *MisskeyInspector) call(ctx context.Context, endpoint string, payload any, response any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/%s", baseURL(i.host), endpoint)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", baseURL(i.host), err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("/api/%s: %w (status code %d)", endpoint, ErrCredentialsRejected, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("/api/%s failed with status code: %d", endpoint, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("/api/%s returned an invalid response: %w", endpoint, err)
+	}
+	return nil
+}
+
+func (a MisskeyAccount) Check(post domain.Post) []string {
+	var problems []string
+	if length := utf8.RuneCountInString(post.Text); a.MaxNoteTextLength > 0 && length > a.MaxNoteTextLength {
+		problems = append(problems, fmt.Sprintf("text is %d characters, the instance allows %d", length, a.MaxNoteTextLength))
+	}
+	if length := utf8.RuneCountInString(post.SpoilerText); a.MaxCwLength > 0 && length > a.MaxCwLength {
+		problems = append(problems, fmt.Sprintf("spoilerText is %d characters, the instance allows %d", length, a.MaxCwLength))
+	}
+	if post.Visibility == "public" && !a.CanPublicNote {
+		problems = append(problems, "visibility is public, but the account is not allowed to post public notes")
+	}
+	return problems
+}
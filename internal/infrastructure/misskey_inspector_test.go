@@ -0,0 +1,83 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMisskeyStandIn(t *testing.T, token string, canWriteNotes bool, me map[string]any) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/i", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["i"] != token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(me)
+	})
+	mux.HandleFunc("POST /api/notes/create", func(w http.ResponseWriter, r *http.Request) {
+		if !canWriteNotes {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "PERMISSION_DENIED"}})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_PARAM"}})
+	})
+	mux.HandleFunc("POST /api/meta", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"version": "2025.4.0", "maxNoteTextLength": 10, "maxCwLength": 5})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMisskeyInspector_Inspect_ReturnsAccountAndLimits(t *testing.T) {
+	server := newMisskeyStandIn(t, "token", true, map[string]any{"username": "hijiki", "policies": map[string]any{"canPublicNote": false}})
+
+	account, err := infrastructure.NewMisskeyInspector(server.URL, "token").Inspect(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, infrastructure.MisskeyAccount{
+		Username:          "hijiki",
+		Version:           "2025.4.0",
+		MaxNoteTextLength: 10,
+		MaxCwLength:       5,
+		CanPublicNote:     false,
+	}, account)
+}
+
+func TestMisskeyInspector_Inspect_WrongToken_ReturnsCredentialsRejected(t *testing.T) {
+	server := newMisskeyStandIn(t, "token", true, map[string]any{"username": "hijiki"})
+
+	_, err := infrastructure.NewMisskeyInspector(server.URL, "typo").Inspect(context.Background())
+
+	assert.True(t, errors.Is(err, infrastructure.ErrCredentialsRejected))
+}
+
+func TestMisskeyInspector_Inspect_ReadOnlyToken_ReturnsCredentialsRejected(t *testing.T) {
+	server := newMisskeyStandIn(t, "token", false, map[string]any{"username": "hijiki"})
+
+	_, err := infrastructure.NewMisskeyInspector(server.URL, "token").Inspect(context.Background())
+
+	require.ErrorIs(t, err, infrastructure.ErrCredentialsRejected)
+	assert.Contains(t, err.Error(), "write:notes")
+}
+
+func TestMisskeyAccount_Check_ReportsLimitViolations(t *testing.T) {
+	account := infrastructure.MisskeyAccount{MaxNoteTextLength: 5, MaxCwLength: 2, CanPublicNote: false}
+
+	assert.Empty(t, account.Check(domain.Post{Text: "ひじきの日", Visibility: "home"}))
+	assert.Len(t, account.Check(domain.Post{Text: "ひじきの日です", SpoilerText: "ネタバレ", Visibility: "public"}), 3)
+}
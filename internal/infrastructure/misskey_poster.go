@@ -2,12 +2,15 @@ package infrastructure
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
 )
 
 type MisskeyPoster struct {
@@ -21,10 +24,17 @@ type MisskeyPoster struct {
 type misskeyPostRequest struct {
 	I          string `json:"i"`
 	Text       string `json:"text"`
+	CW         string `json:"cw,omitempty"`
 	Visibility string `json:"visibility"`
 	LocalOnly  bool   `json:"localOnly,omitempty"`
 }
 
+type misskeyPostResponse struct {
+	CreatedNote struct {
+		ID string `json:"id"`
+	} `json:"createdNote"`
+}
+
 func NewMisskeyPoster(config ports.Config) ports.Poster {
 	return &MisskeyPoster{
 		host:       config.MisskeyHost,
@@ -35,35 +45,47 @@ func NewMisskeyPoster(config ports.Config) ports.Poster {
 	}
 }
 
-func (p *MisskeyPoster) Post(content string) error {
+func (p *MisskeyPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
 	request := misskeyPostRequest{
 		I:          p.token,
-		Text:       content,
-		Visibility: p.visibility,
+		Text:       post.Text,
+		CW:         post.SpoilerText,
+		Visibility: defaultString(post.Visibility, p.visibility),
 		LocalOnly:  p.localOnly,
 	}
 
 	body, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return domain.PostResult{}, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("https://%s/api/notes/create", p.host)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	url := fmt.Sprintf("%s/api/notes/create", baseURL(p.host))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return domain.PostResult{}, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return domain.PostResult{}, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+		return domain.PostResult{}, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+	}
+
+	var response misskeyPostResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to decode note response: %w", err)
 	}
+	return domain.PostResult{ID: response.CreatedNote.ID}, nil
+}
 
-	return nil
+func baseURL(host string) string {
+	if strings.Contains(host, "://") {
+		return strings.TrimRight(host, "/")
+	}
+	return "https://" + host
 }
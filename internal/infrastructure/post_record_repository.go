@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+var ErrReadOnlyRecords = errors.New("post records are opened read-only")
+
+var sqliteRecordsExtensions = map[string]bool{".db": true, ".sqlite": true, ".sqlite3": true}
+
+func IsSQLiteRecordsPath(path string) bool {
+	return sqliteRecordsExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
+func OpenPostRecordRepository(path string) (ports.PostRecordRepository, error) {
+	if IsSQLiteRecordsPath(path) {
+		return NewSQLitePostRecordRepository(path)
+	}
+	return NewJSONPostRecordRepository(path), nil
+}
+
+func OpenReadOnlyPostRecordRepository(path string) (ports.PostRecordRepository, error) {
+	if !IsSQLiteRecordsPath(path) {
+		return NewReadOnlyJSONPostRecordRepository(path), nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return EmptyPostRecordRepository{}, nil
+	}
+	return NewReadOnlySQLitePostRecordRepository(path)
+}
+
+type EmptyPostRecordRepository struct{}
+
+func (EmptyPostRecordRepository) Find(scheduleID string) (domain.PostRecord, error) {
+	return domain.PostRecord{}, nil
+}
+
+func (EmptyPostRecordRepository) Save(record domain.PostRecord) error {
+	return ErrReadOnlyRecords
+}
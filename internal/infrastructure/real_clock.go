@@ -8,10 +8,26 @@ import (
 
 type RealClock struct{}
 
-func NewRealClock() ports.Clock {
+func NewRealClock() *RealClock {
 	return &RealClock{}
 }
 
 func (c *RealClock) Now() time.Time {
 	return time.Now()
 }
+
+func (c *RealClock) NewTimer(d time.Duration) ports.Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *realTimer) Stop() bool {
+	return t.timer.Stop()
+}
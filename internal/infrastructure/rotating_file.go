@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+	mutex      sync.Mutex
+}
+
+func OpenRotatingFile(path string, maxSize int64, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var rotateErr error
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		rotateErr = r.rotate()
+	}
+	if r.file == nil {
+		return 0, errors.Join(rotateErr, os.ErrClosed)
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, errors.Join(rotateErr, err)
+}
+
+func (r *RotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	closeErr := r.file.Close()
+	r.file = nil
+
+	var err error
+	if closeErr != nil {
+		err = fmt.Errorf("failed to rotate %s: %w", r.path, closeErr)
+	} else {
+		err = r.moveToBackups()
+	}
+	return errors.Join(err, r.open())
+}
+
+func (r *RotatingFile) moveToBackups() error {
+	if r.maxBackups > 0 {
+		os.Remove(r.backupPath(r.maxBackups))
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			if err := os.Rename(r.backupPath(i), r.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to rotate %s: %w", r.path, err)
+			}
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+			return fmt.Errorf("failed to rotate %s: %w", r.path, err)
+		}
+	} else if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate %s: %w", r.path, err)
+	}
+	return nil
+}
+
+func (r *RotatingFile) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", r.path, index)
+}
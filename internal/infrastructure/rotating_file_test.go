@@ -0,0 +1,77 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_RotatesWhenMaxSizeIsExceeded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hijiki.log")
+	file, err := infrastructure.OpenRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer file.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := file.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	assertFileContent(t, path, "fourth\n")
+	assertFileContent(t, path+".1", "third\n")
+	assertFileContent(t, path+".2", "second\n")
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestRotatingFile_WhenRotationFails_KeepsWritingToOriginalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hijiki.log")
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0755))
+	file, err := infrastructure.OpenRotatingFile(path, 10, 1)
+	require.NoError(t, err)
+	defer file.Close()
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+	n, err := file.Write([]byte("second\n"))
+	assert.ErrorContains(t, err, "failed to rotate")
+	assert.Equal(t, len("second\n"), n)
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = file.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	assertFileContent(t, path, "third\n")
+	assertFileContent(t, path+".1", "first\nsecond\n")
+}
+
+func TestRotatingFile_AppendsToExistingFileWithPrivateMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hijiki.log")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0600))
+
+	file, err := infrastructure.OpenRotatingFile(path, 0, 0)
+	require.NoError(t, err)
+	_, err = file.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	assertFileContent(t, path, "old\nnew\n")
+
+	newPath := filepath.Join(t.TempDir(), "fresh.log")
+	file, err = infrastructure.OpenRotatingFile(newPath, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	info, err := os.Stat(newPath)
+	require.NoError(t, err)
+	assert.Zero(t, info.Mode().Perm()&0007)
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(data))
+}
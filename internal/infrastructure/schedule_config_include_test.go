@@ -0,0 +1,143 @@
+package infrastructure_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeIncludeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestScheduleConfigLoader_Load_MergesIncludedFiles(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json": `{
+			"include": ["accounts.json", "schedules/*.yaml"],
+			"schedules": [{"id": "root", "type": "daily", "hour": 8, "minute": 0, "content": "root"}]
+		}`,
+		"accounts.json":    `{"accounts": [{"name": "mastodon", "type": "mastodon", "host": "mastodon.example", "token": "t"}]}`,
+		"schedules/b.yaml": "schedules:\n  - {id: b, type: daily, hour: 9, minute: 0, content: b, account: mastodon}\n",
+		"schedules/a.yaml": "schedules:\n  - {id: a, type: daily, hour: 10, minute: 0, content: a}\n",
+	})
+
+	loader := infrastructure.NewScheduleConfigLoader(filepath.Join(dir, "config.json"))
+	configs, err := loader.Load()
+
+	require.NoError(t, err)
+	require.Len(t, configs, 3)
+	assert.Equal(t, "root", configs[0].ID)
+	assert.Equal(t, "a", configs[1].ID)
+	assert.Equal(t, filepath.Join(dir, "schedules", "a.yaml"), configs[1].Source)
+	assert.Equal(t, "b", configs[2].ID)
+	assert.Equal(t, "mastodon", configs[2].Account)
+}
+
+func TestScheduleConfigLoader_Validate_DuplicateIDAcrossFiles_ReportsFile(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json": `{
+			"include": ["extra.json"],
+			"schedules": [{"id": "same", "type": "daily", "hour": 8, "minute": 0, "content": "root"}]
+		}`,
+		"extra.json": `{"schedules": [{"id": "same", "type": "daily", "hour": 9, "minute": 0, "content": "extra"}]}`,
+	})
+
+	loader := infrastructure.NewScheduleConfigLoader(filepath.Join(dir, "config.json"))
+	issues, err := loader.Validate()
+
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, filepath.Join(dir, "extra.json"), issues[0].File)
+	assert.Equal(t, "$.schedules[0].id", issues[0].Path)
+}
+
+func TestScheduleConfigLoader_Load_MissingInclude_ReturnsError(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json": `{"include": ["missing.json"]}`,
+	})
+
+	loader := infrastructure.NewScheduleConfigLoader(filepath.Join(dir, "config.json"))
+	_, err := loader.Load()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "included file not found")
+}
+
+func TestScheduleConfigLoader_Load_IncludedTwice_ReturnsError(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json": `{"include": ["a.json", "b.json"]}`,
+		"a.json":      `{"include": ["b.json"]}`,
+		"b.json":      `{}`,
+	})
+
+	loader := infrastructure.NewScheduleConfigLoader(filepath.Join(dir, "config.json"))
+	_, err := loader.Load()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "included more than once")
+}
+
+func TestScheduleConfigLoader_WatchPaths_IncludesFilesAndDirectories(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json":      `{"include": ["schedules/*.json"]}`,
+		"schedules/a.json": `{}`,
+	})
+	configPath := filepath.Join(dir, "config.json")
+
+	paths, err := infrastructure.NewScheduleConfigLoader(configPath).WatchPaths()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		configPath,
+		filepath.Join(dir, "schedules", "a.json"),
+		filepath.Join(dir, "schedules"),
+	}, paths)
+}
+
+func TestScheduleConfigLoader_LoadAll_ReadsEverythingFromOneSnapshot(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json": `{
+			"include": ["accounts.json"],
+			"schedules": [{"id": "daily", "type": "daily", "hour": 8, "minute": 0, "content": "x", "account": "sub"}],
+			"muteWindows": [{"start": "23:00", "end": "06:00"}],
+			"alerts": {"to": "@admin"}
+		}`,
+		"accounts.json": `{"accounts": [{"name": "sub", "host": "sub.example", "token": "t"}]}`,
+	})
+	configPath := filepath.Join(dir, "config.json")
+
+	config, err := infrastructure.NewScheduleConfigLoader(configPath).LoadAll()
+
+	require.NoError(t, err)
+	require.Len(t, config.Schedules, 1)
+	assert.Equal(t, "sub", config.Schedules[0].Account)
+	require.Len(t, config.Accounts, 1)
+	assert.Equal(t, "sub.example", config.Accounts[0].Host)
+	require.Len(t, config.MuteWindows, 1)
+	assert.True(t, config.AlertsEnabled)
+	assert.Equal(t, "@admin", config.Alerts.To)
+	assert.Equal(t, []string{configPath, filepath.Join(dir, "accounts.json"), dir}, config.WatchPaths)
+}
+
+func TestScheduleConfigLoader_LoadAll_InvalidIncludedFile_ReturnsValidationError(t *testing.T) {
+	dir := writeIncludeFiles(t, map[string]string{
+		"config.json":   `{"include": ["accounts.json"], "schedules": [{"id": "daily", "type": "daily", "hour": 8, "minute": 0, "content": "x"}]}`,
+		"accounts.json": `{"accounts": [{"name": "sub"}, {"name": "sub"}]}`,
+	})
+
+	_, err := infrastructure.NewScheduleConfigLoader(filepath.Join(dir, "config.json")).LoadAll()
+
+	var validationErr *infrastructure.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+}
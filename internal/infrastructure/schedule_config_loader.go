@@ -1,55 +1,361 @@
 package infrastructure
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/joho/godotenv"
 )
 
 type ScheduleConfig struct {
-	ID       string
-	Schedule domain.Schedule
-	Content  string
+	Source      string
+	ID          string
+	Schedule    domain.Schedule
+	Content     string
+	Account     string
+	SpoilerText string
+	Visibility  string
+	MediaPaths  []string
+}
+
+type AccountConfig struct {
+	Name         string
+	Type         string
+	Host         string
+	Handle       string
+	Token        string
+	Visibility   string
+	LocalOnly    bool
+	Provider     string
+	URL          string
+	Headers      map[string]string
+	BodyTemplate string
+}
+
+type AlertConfig struct {
+	Account     string
+	To          string
+	Visibility  string
+	Threshold   int
+	RepeatAfter time.Duration
+}
+
+const (
+	DefaultAlertThreshold  = 1
+	DefaultAlertVisibility = "specified"
+)
+
+type LoadedConfig struct {
+	Schedules     []ScheduleConfig
+	Accounts      []AccountConfig
+	MuteWindows   []domain.MuteWindow
+	Alerts        AlertConfig
+	AlertsEnabled bool
+	Warnings      []ValidationIssue
+	WatchPaths    []string
 }
 
 type ScheduleConfigLoader struct {
 	filePath string
+	envPath  string
 }
 
 type scheduleConfigFile struct {
-	Schedules []scheduleConfigEntry `json:"schedules"`
+	Schema      string                `json:"$schema,omitempty" yaml:"$schema,omitempty" toml:"$schema,omitempty"`
+	Include     []string              `json:"include,omitempty" yaml:"include,omitempty" toml:"include,omitempty"`
+	Accounts    []accountConfigEntry  `json:"accounts,omitempty" yaml:"accounts,omitempty" toml:"accounts,omitempty"`
+	Schedules   []scheduleConfigEntry `json:"schedules" yaml:"schedules" toml:"schedules"`
+	MuteWindows []muteWindowEntry     `json:"muteWindows,omitempty" yaml:"muteWindows,omitempty" toml:"muteWindows,omitempty"`
+	Alerts      *alertsEntry          `json:"alerts,omitempty" yaml:"alerts,omitempty" toml:"alerts,omitempty"`
+	files       []string
+	alertFiles  []string
+	watchDirs   []string
+	lookupEnv   envLookup
+}
+
+type entryOrigin struct {
+	File  string
+	Index int
+}
+
+type accountConfigEntry struct {
+	Name         string            `json:"name" yaml:"name" toml:"name"`
+	Type         string            `json:"type,omitempty" yaml:"type,omitempty" toml:"type,omitempty"`
+	Host         string            `json:"host,omitempty" yaml:"host,omitempty" toml:"host,omitempty"`
+	Handle       string            `json:"handle,omitempty" yaml:"handle,omitempty" toml:"handle,omitempty"`
+	Token        string            `json:"token,omitempty" yaml:"token,omitempty" toml:"token,omitempty"`
+	Visibility   string            `json:"visibility,omitempty" yaml:"visibility,omitempty" toml:"visibility,omitempty"`
+	LocalOnly    bool              `json:"localOnly,omitempty" yaml:"localOnly,omitempty" toml:"localOnly,omitempty"`
+	Provider     string            `json:"provider,omitempty" yaml:"provider,omitempty" toml:"provider,omitempty"`
+	URL          string            `json:"url,omitempty" yaml:"url,omitempty" toml:"url,omitempty"`
+	Headers      map[string]string `json:"headers,omitempty" yaml:"headers,omitempty" toml:"headers,omitempty"`
+	BodyTemplate string            `json:"bodyTemplate,omitempty" yaml:"bodyTemplate,omitempty" toml:"bodyTemplate,omitempty"`
+	origin       entryOrigin
 }
 
 type scheduleConfigEntry struct {
-	ID         string `json:"id"`
-	Type       string `json:"type"`
-	Hour       int    `json:"hour"`
-	Minute     int    `json:"minute"`
-	DayOfWeek  int    `json:"dayOfWeek"`
-	DayOfMonth int    `json:"dayOfMonth"`
-	Month      int    `json:"month"`
-	Content    string `json:"content"`
+	ID          string   `json:"id" yaml:"id" toml:"id"`
+	Type        string   `json:"type" yaml:"type" toml:"type"`
+	Hour        int      `json:"hour" yaml:"hour" toml:"hour"`
+	Minute      int      `json:"minute" yaml:"minute" toml:"minute"`
+	DayOfWeek   int      `json:"dayOfWeek,omitempty" yaml:"dayOfWeek,omitempty" toml:"dayOfWeek,omitzero"`
+	DayOfMonth  int      `json:"dayOfMonth,omitempty" yaml:"dayOfMonth,omitempty" toml:"dayOfMonth,omitzero"`
+	Month       int      `json:"month,omitempty" yaml:"month,omitempty" toml:"month,omitzero"`
+	Content     string   `json:"content" yaml:"content" toml:"content"`
+	Account     string   `json:"account,omitempty" yaml:"account,omitempty" toml:"account,omitempty"`
+	SpoilerText string   `json:"spoilerText,omitempty" yaml:"spoilerText,omitempty" toml:"spoilerText,omitempty"`
+	Visibility  string   `json:"visibility,omitempty" yaml:"visibility,omitempty" toml:"visibility,omitempty"`
+	Media       []string `json:"media,omitempty" yaml:"media,omitempty" toml:"media,omitempty"`
+	origin      entryOrigin
+}
+
+type muteWindowEntry struct {
+	Start  string `json:"start" yaml:"start" toml:"start"`
+	End    string `json:"end" yaml:"end" toml:"end"`
+	Policy string `json:"policy,omitempty" yaml:"policy,omitempty" toml:"policy,omitempty"`
+	origin entryOrigin
+}
+
+type alertsEntry struct {
+	Account     string `json:"account,omitempty" yaml:"account,omitempty" toml:"account,omitempty"`
+	To          string `json:"to,omitempty" yaml:"to,omitempty" toml:"to,omitempty"`
+	Visibility  string `json:"visibility,omitempty" yaml:"visibility,omitempty" toml:"visibility,omitempty"`
+	Threshold   int    `json:"threshold,omitempty" yaml:"threshold,omitempty" toml:"threshold,omitzero"`
+	RepeatAfter string `json:"repeatAfter,omitempty" yaml:"repeatAfter,omitempty" toml:"repeatAfter,omitempty"`
+	origin      entryOrigin
 }
 
 func NewScheduleConfigLoader(filePath string) *ScheduleConfigLoader {
 	return &ScheduleConfigLoader{filePath: filePath}
 }
 
+func (l *ScheduleConfigLoader) SetEnvFile(envPath string) {
+	l.envPath = envPath
+}
+
 func (l *ScheduleConfigLoader) Load() ([]ScheduleConfig, error) {
-	data, err := os.ReadFile(l.filePath)
+	configFile, err := l.readValidConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return l.convertToScheduleConfigs(configFile.Schedules)
+}
+
+func (l *ScheduleConfigLoader) LoadAccounts() ([]AccountConfig, error) {
+	configFile, err := l.readValidConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return l.convertToAccountConfigs(configFile.Accounts, configFile.lookupEnv), nil
+}
+
+func (l *ScheduleConfigLoader) LoadMuteWindows() ([]domain.MuteWindow, error) {
+	configFile, err := l.readValidConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToMuteWindows(configFile.MuteWindows), nil
+}
+
+func (l *ScheduleConfigLoader) LoadAlerts() (AlertConfig, bool, error) {
+	configFile, err := l.readValidConfigFile()
+	if err != nil || configFile.Alerts == nil {
+		return AlertConfig{}, false, err
+	}
+
+	return convertToAlertConfig(*configFile.Alerts), true, nil
+}
+
+func (l *ScheduleConfigLoader) LoadAll() (LoadedConfig, error) {
+	configFile, err := l.readConfigFile()
+	if err != nil {
+		return LoadedConfig{}, err
+	}
+
+	issues := validateScheduleConfigFile(configFile)
+	if HasValidationErrors(issues) {
+		return LoadedConfig{}, &ValidationError{Issues: issues}
+	}
+
+	schedules, err := l.convertToScheduleConfigs(configFile.Schedules)
+	if err != nil {
+		return LoadedConfig{}, err
+	}
+
+	config := LoadedConfig{
+		Schedules:   schedules,
+		Accounts:    l.convertToAccountConfigs(configFile.Accounts, configFile.lookupEnv),
+		MuteWindows: convertToMuteWindows(configFile.MuteWindows),
+		Warnings:    issues,
+		WatchPaths:  append(configFile.files, configFile.watchDirs...),
+	}
+	if configFile.Alerts != nil {
+		config.Alerts = convertToAlertConfig(*configFile.Alerts)
+		config.AlertsEnabled = true
+	}
+	return config, nil
+}
+
+func (l *ScheduleConfigLoader) Validate() ([]ValidationIssue, error) {
+	configFile, err := l.readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return validateScheduleConfigFile(configFile), nil
+}
+
+func (l *ScheduleConfigLoader) readValidConfigFile() (scheduleConfigFile, error) {
+	configFile, err := l.readConfigFile()
+	if err != nil {
+		return configFile, err
+	}
+
+	issues := validateScheduleConfigFile(configFile)
+	if HasValidationErrors(issues) {
+		return configFile, &ValidationError{Issues: issues}
+	}
+
+	return configFile, nil
+}
+
+func (l *ScheduleConfigLoader) WatchPaths() ([]string, error) {
+	configFile, err := l.readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(configFile.files, configFile.watchDirs...), nil
+}
+
+func (l *ScheduleConfigLoader) readConfigFile() (scheduleConfigFile, error) {
+	var merged scheduleConfigFile
+	if err := l.mergeConfigFile(l.filePath, &merged, make(map[string]bool)); err != nil {
+		return merged, err
+	}
+
+	var envValues map[string]string
+	if l.envPath != "" {
+		values, err := godotenv.Read(l.envPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return merged, fmt.Errorf("failed to read env file %s: %w", l.envPath, err)
+		}
+		envValues = values
+	}
+	merged.lookupEnv = newEnvLookup(envValues)
+	return merged, nil
+}
+
+func (l *ScheduleConfigLoader) mergeConfigFile(path string, merged *scheduleConfigFile, visited map[string]bool) error {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	if visited[absolutePath] {
+		return fmt.Errorf("config file included more than once: %s", path)
+	}
+	visited[absolutePath] = true
+
+	configFile, err := readSingleConfigFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return err
+	}
+
+	for index := range configFile.Accounts {
+		configFile.Accounts[index].origin = entryOrigin{File: path, Index: index}
+	}
+	for index := range configFile.Schedules {
+		configFile.Schedules[index].origin = entryOrigin{File: path, Index: index}
 	}
+	merged.files = append(merged.files, path)
+	merged.Accounts = append(merged.Accounts, configFile.Accounts...)
+	for index := range configFile.MuteWindows {
+		configFile.MuteWindows[index].origin = entryOrigin{File: path, Index: index}
+	}
+	merged.Schedules = append(merged.Schedules, configFile.Schedules...)
+	merged.MuteWindows = append(merged.MuteWindows, configFile.MuteWindows...)
+	if configFile.Alerts != nil {
+		configFile.Alerts.origin = entryOrigin{File: path}
+		merged.alertFiles = append(merged.alertFiles, path)
+		if merged.Alerts == nil {
+			merged.Alerts = configFile.Alerts
+		}
+	}
+
+	for _, pattern := range configFile.Include {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid include pattern in %s: %w", path, err)
+		}
+		if len(matches) == 0 && !hasGlobMeta(pattern) {
+			return fmt.Errorf("included file not found in %s: %s", path, pattern)
+		}
+		merged.watchDirs = append(merged.watchDirs, filepath.Dir(pattern))
 
+		sort.Strings(matches)
+		for _, match := range matches {
+			if err := l.mergeConfigFile(match, merged, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func readSingleConfigFile(path string) (scheduleConfigFile, error) {
 	var configFile scheduleConfigFile
-	if err := json.Unmarshal(data, &configFile); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+
+	format, err := ConfigFormatOf(path)
+	if err != nil {
+		return configFile, err
 	}
 
-	return l.convertToScheduleConfigs(configFile.Schedules)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return configFile, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := decodeConfigFile(format, data, &configFile); err != nil {
+		return configFile, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return configFile, nil
+}
+
+func parseTimeOfDay(value string) (int, int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return parsed.Hour(), parsed.Minute(), nil
+}
+
+func parseRepeatAfter(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected a positive duration like 6h", value)
+	}
+	return duration, nil
+}
+
+func hasGlobMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
 }
 
 func (l *ScheduleConfigLoader) convertToScheduleConfigs(entries []scheduleConfigEntry) ([]ScheduleConfig, error) {
@@ -62,15 +368,76 @@ func (l *ScheduleConfigLoader) convertToScheduleConfigs(entries []scheduleConfig
 		}
 
 		configs = append(configs, ScheduleConfig{
-			ID:       entry.ID,
-			Schedule: schedule,
-			Content:  entry.Content,
+			Source:      entry.origin.File,
+			ID:          entry.ID,
+			Schedule:    schedule,
+			Content:     entry.Content,
+			Account:     entry.Account,
+			SpoilerText: entry.SpoilerText,
+			Visibility:  entry.Visibility,
+			MediaPaths:  entry.Media,
 		})
 	}
 
 	return configs, nil
 }
 
+func convertToMuteWindows(entries []muteWindowEntry) []domain.MuteWindow {
+	windows := make([]domain.MuteWindow, 0, len(entries))
+	for _, entry := range entries {
+		startHour, startMinute, _ := parseTimeOfDay(entry.Start)
+		endHour, endMinute, _ := parseTimeOfDay(entry.End)
+		policy := domain.MutePolicy(defaultString(entry.Policy, string(domain.MutePolicySkip)))
+		windows = append(windows, domain.NewMuteWindow(startHour, startMinute, endHour, endMinute, policy))
+	}
+	return windows
+}
+
+func convertToAlertConfig(entry alertsEntry) AlertConfig {
+	threshold := entry.Threshold
+	if threshold == 0 {
+		threshold = DefaultAlertThreshold
+	}
+	repeatAfter, _ := parseRepeatAfter(entry.RepeatAfter)
+	return AlertConfig{
+		Account:     defaultString(entry.Account, DefaultAccountName),
+		To:          entry.To,
+		Visibility:  defaultString(entry.Visibility, DefaultAlertVisibility),
+		Threshold:   threshold,
+		RepeatAfter: repeatAfter,
+	}
+}
+
+func (l *ScheduleConfigLoader) convertToAccountConfigs(entries []accountConfigEntry, lookup envLookup) []AccountConfig {
+	accounts := make([]AccountConfig, 0, len(entries))
+
+	for _, entry := range entries {
+		var headers map[string]string
+		if entry.Headers != nil {
+			headers = make(map[string]string, len(entry.Headers))
+			for name, value := range entry.Headers {
+				headers[name] = expandEnvReferences(value, lookup)
+			}
+		}
+
+		accounts = append(accounts, AccountConfig{
+			Name:         entry.Name,
+			Type:         entry.Type,
+			Host:         expandEnvReferences(entry.Host, lookup),
+			Handle:       expandEnvReferences(entry.Handle, lookup),
+			Token:        expandEnvReferences(entry.Token, lookup),
+			Visibility:   entry.Visibility,
+			LocalOnly:    entry.LocalOnly,
+			Provider:     entry.Provider,
+			URL:          expandEnvReferences(entry.URL, lookup),
+			Headers:      headers,
+			BodyTemplate: entry.BodyTemplate,
+		})
+	}
+
+	return accounts
+}
+
 func (l *ScheduleConfigLoader) createSchedule(entry scheduleConfigEntry) (domain.Schedule, error) {
 	switch entry.Type {
 	case "daily":
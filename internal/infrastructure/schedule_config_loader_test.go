@@ -2,6 +2,7 @@ package infrastructure_test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -201,6 +202,140 @@ func TestScheduleConfigLoader_Load_DailySchedule_VerifyNextTime(t *testing.T) {
 	assert.Equal(t, time.Date(2026, 2, 1, 15, 30, 0, 0, time.UTC), nextTime)
 }
 
+func TestScheduleConfigLoader_Load_ScheduleWithAccountOptions(t *testing.T) {
+	configJSON := `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon", "host": "mastodon.example", "token": "t"}
+		],
+		"schedules": [
+			{
+				"id": "crosspost",
+				"type": "daily",
+				"hour": 12,
+				"minute": 37,
+				"content": "ひじき",
+				"account": "mastodon",
+				"spoilerText": "人生のネタバレ",
+				"visibility": "public",
+				"media": ["hijiki.png"]
+			}
+		]
+	}`
+	filePath := createTempConfigFile(t, configJSON)
+	defer os.Remove(filePath)
+
+	loader := infrastructure.NewScheduleConfigLoader(filePath)
+	configs, err := loader.Load()
+
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "mastodon", configs[0].Account)
+	assert.Equal(t, "人生のネタバレ", configs[0].SpoilerText)
+	assert.Equal(t, "public", configs[0].Visibility)
+	assert.Equal(t, []string{"hijiki.png"}, configs[0].MediaPaths)
+}
+
+func TestScheduleConfigLoader_LoadAccounts(t *testing.T) {
+	configJSON := `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon", "host": "mastodon.example", "token": "t", "visibility": "public"}
+		],
+		"schedules": []
+	}`
+	filePath := createTempConfigFile(t, configJSON)
+	defer os.Remove(filePath)
+
+	loader := infrastructure.NewScheduleConfigLoader(filePath)
+	accounts, err := loader.LoadAccounts()
+
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, infrastructure.AccountConfig{
+		Name:       "mastodon",
+		Type:       "mastodon",
+		Host:       "mastodon.example",
+		Token:      "t",
+		Visibility: "public",
+	}, accounts[0])
+}
+
+func TestScheduleConfigLoader_LoadAccounts_DuplicateName_ReturnsError(t *testing.T) {
+	configJSON := `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon"},
+			{"name": "mastodon", "type": "mastodon"}
+		]
+	}`
+	filePath := createTempConfigFile(t, configJSON)
+	defer os.Remove(filePath)
+
+	loader := infrastructure.NewScheduleConfigLoader(filePath)
+	_, err := loader.LoadAccounts()
+
+	require.Error(t, err)
+}
+
+func TestScheduleConfigLoader_LoadAccounts_ExpandsEnvReferences(t *testing.T) {
+	t.Setenv("HIJIKI_MASTODON_TOKEN", "secret-token")
+	t.Setenv("HIJIKI_WEBHOOK_KEY", "secret-key")
+	configJSON := `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon", "host": "mastodon.example", "token": "${HIJIKI_MASTODON_TOKEN}"},
+			{"name": "hook", "type": "webhook", "url": "https://hooks.example/${HIJIKI_WEBHOOK_KEY}", "headers": {"Authorization": "Bearer ${HIJIKI_WEBHOOK_KEY}"}}
+		]
+	}`
+	filePath := createTempConfigFile(t, configJSON)
+	defer os.Remove(filePath)
+
+	accounts, err := infrastructure.NewScheduleConfigLoader(filePath).LoadAccounts()
+
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "secret-token", accounts[0].Token)
+	assert.Equal(t, "https://hooks.example/secret-key", accounts[1].URL)
+	assert.Equal(t, "Bearer secret-key", accounts[1].Headers["Authorization"])
+}
+
+func TestScheduleConfigLoader_LoadAccounts_UndefinedEnvReference_ReturnsError(t *testing.T) {
+	configJSON := `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon", "host": "mastodon.example", "token": "${HIJIKI_UNDEFINED_TOKEN}"}
+		]
+	}`
+	filePath := createTempConfigFile(t, configJSON)
+	defer os.Remove(filePath)
+
+	_, err := infrastructure.NewScheduleConfigLoader(filePath).LoadAccounts()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$.accounts[0].token")
+	assert.Contains(t, err.Error(), "HIJIKI_UNDEFINED_TOKEN")
+}
+
+func TestScheduleConfigLoader_LoadAccounts_ExpandsEnvReferencesFromEnvFile(t *testing.T) {
+	t.Setenv("HIJIKI_MASTODON_HOST", "process.example")
+	envPath := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envPath, []byte("HIJIKI_MASTODON_HOST=file.example\nHIJIKI_FILE_ONLY_TOKEN=file-token\n"), 0600))
+	filePath := createTempConfigFile(t, `{
+		"accounts": [
+			{"name": "mastodon", "type": "mastodon", "host": "${HIJIKI_MASTODON_HOST}", "token": "${HIJIKI_FILE_ONLY_TOKEN}"}
+		]
+	}`)
+	defer os.Remove(filePath)
+	loader := infrastructure.NewScheduleConfigLoader(filePath)
+
+	_, err := loader.LoadAccounts()
+	require.ErrorContains(t, err, "HIJIKI_FILE_ONLY_TOKEN")
+
+	loader.SetEnvFile(envPath)
+	accounts, err := loader.LoadAccounts()
+
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "process.example", accounts[0].Host)
+	assert.Equal(t, "file-token", accounts[0].Token)
+}
+
 func createTempConfigFile(t *testing.T, content string) string {
 	t.Helper()
 	file, err := os.CreateTemp("", "config-*.json")
@@ -210,3 +345,55 @@ func createTempConfigFile(t *testing.T, content string) string {
 	file.Close()
 	return file.Name()
 }
+
+func TestScheduleConfigLoader_LoadMuteWindows(t *testing.T) {
+	filePath := createTempConfigFile(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}],
+		"muteWindows": [
+			{"start": "23:30", "end": "06:00", "policy": "defer"},
+			{"start": "12:00", "end": "13:00"}
+		]
+	}`)
+	defer os.Remove(filePath)
+
+	windows, err := infrastructure.NewScheduleConfigLoader(filePath).LoadMuteWindows()
+
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	assert.Equal(t, "23:30-06:00", windows[0].String())
+	assert.Equal(t, domain.MutePolicyDefer, windows[0].Policy)
+	assert.True(t, windows[0].Contains(time.Date(2026, 2, 1, 2, 0, 0, 0, time.Local)))
+	assert.Equal(t, domain.MutePolicySkip, windows[1].Policy)
+}
+
+func TestScheduleConfigLoader_LoadAlerts(t *testing.T) {
+	filePath := createTempConfigFile(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}],
+		"alerts": {"to": "@admin", "repeatAfter": "6h"}
+	}`)
+	defer os.Remove(filePath)
+
+	alerts, ok, err := infrastructure.NewScheduleConfigLoader(filePath).LoadAlerts()
+
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, infrastructure.AlertConfig{
+		Account:     infrastructure.DefaultAccountName,
+		To:          "@admin",
+		Visibility:  infrastructure.DefaultAlertVisibility,
+		Threshold:   infrastructure.DefaultAlertThreshold,
+		RepeatAfter: 6 * time.Hour,
+	}, alerts)
+}
+
+func TestScheduleConfigLoader_LoadAlerts_NotConfigured(t *testing.T) {
+	filePath := createTempConfigFile(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}]
+	}`)
+	defer os.Remove(filePath)
+
+	_, ok, err := infrastructure.NewScheduleConfigLoader(filePath).LoadAlerts()
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,346 @@
+package infrastructure
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ValidationSeverity string
+
+const (
+	SeverityError   ValidationSeverity = "error"
+	SeverityWarning ValidationSeverity = "warning"
+)
+
+var (
+	supportedScheduleTypes = map[string]bool{"daily": true, "weekly": true, "monthly": true, "yearly": true}
+	supportedMutePolicies  = map[string]bool{"skip": true, "defer": true}
+	supportedAccountTypes  = map[string]bool{"misskey": true, "mastodon": true, "bluesky": true, "webhook": true}
+	supportedVisibilities  = map[string]bool{
+		"public": true, "home": true, "followers": true, "specified": true,
+		"unlisted": true, "private": true, "direct": true,
+	}
+	accountVisibilities = map[string]map[string]bool{
+		"misskey": {"public": true, "home": true, "followers": true, "specified": true},
+		"mastodon": {
+			"public": true, "unlisted": true, "private": true, "direct": true,
+			"home": true, "followers": true, "specified": true,
+		},
+		"bluesky": {},
+		"webhook": supportedVisibilities,
+	}
+)
+
+type ValidationIssue struct {
+	Severity ValidationSeverity
+	File     string
+	Index    int
+	ID       string
+	Path     string
+	Message  string
+}
+
+func (i ValidationIssue) String() string {
+	location := i.Path
+	if i.ID != "" {
+		location = fmt.Sprintf("%s (id=%s)", i.Path, i.ID)
+	}
+	if i.File != "" {
+		return fmt.Sprintf("%s: %s: %s: %s", i.File, i.Severity, location, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, location, i.Message)
+}
+
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			messages = append(messages, issue.String())
+		}
+	}
+	return fmt.Sprintf("invalid config (%d error(s)):\n  %s", len(messages), strings.Join(messages, "\n  "))
+}
+
+func HasValidationErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+type scheduleConfigValidator struct {
+	issues    []ValidationIssue
+	lookupEnv envLookup
+}
+
+func validateScheduleConfigFile(configFile scheduleConfigFile) []ValidationIssue {
+	validator := &scheduleConfigValidator{lookupEnv: configFile.lookupEnv}
+	accountTypes := validator.validateAccounts(configFile.Accounts)
+	validator.validateSchedules(configFile.Schedules, accountTypes)
+	validator.validateMuteWindows(configFile.MuteWindows)
+	validator.validateAlerts(configFile.Alerts, configFile.alertFiles, accountTypes)
+	return validator.issues
+}
+
+func (v *scheduleConfigValidator) report(severity ValidationSeverity, origin entryOrigin, id, path, format string, args ...any) {
+	v.issues = append(v.issues, ValidationIssue{
+		Severity: severity,
+		File:     origin.File,
+		Index:    origin.Index,
+		ID:       id,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *scheduleConfigValidator) validateAccounts(entries []accountConfigEntry) map[string]string {
+	accountTypes := map[string]string{DefaultAccountName: "misskey"}
+	firstOriginByName := make(map[string]entryOrigin, len(entries))
+
+	for _, entry := range entries {
+		path := fmt.Sprintf("$.accounts[%d]", entry.origin.Index)
+		accountType := defaultString(entry.Type, "misskey")
+		fail := func(field, format string, args ...any) {
+			v.report(SeverityError, entry.origin, entry.Name, path+field, format, args...)
+		}
+
+		switch {
+		case entry.Name == "":
+			fail(".name", "name is required")
+		case entry.Name == DefaultAccountName:
+			fail(".name", "%q is reserved for the account configured in .env", DefaultAccountName)
+		default:
+			if firstOrigin, exists := firstOriginByName[entry.Name]; exists {
+				fail(".name", "duplicate account name, first defined at %s", describeOrigin(firstOrigin, "accounts"))
+			} else {
+				firstOriginByName[entry.Name] = entry.origin
+				accountTypes[entry.Name] = accountType
+			}
+		}
+
+		v.validateEnvReferences(entry, fail)
+
+		if !supportedAccountTypes[accountType] {
+			fail(".type", "unknown account type %q", entry.Type)
+			continue
+		}
+		validateVisibility(entry.Visibility, accountType, true, fail)
+
+		switch accountType {
+		case "misskey", "mastodon":
+			if entry.Host == "" {
+				fail(".host", "host is required for %s accounts", accountType)
+			}
+			if entry.Token == "" {
+				fail(".token", "token is required for %s accounts", accountType)
+			}
+		case "bluesky":
+			if entry.Handle == "" {
+				fail(".handle", "handle is required for bluesky accounts")
+			}
+			if entry.Token == "" {
+				fail(".token", "app password is required for bluesky accounts")
+			}
+		case "webhook":
+			if entry.URL == "" {
+				fail(".url", "url is required for webhook accounts")
+			}
+			if _, err := NewWebhookPoster(WebhookConfig{URL: entry.URL, Provider: entry.Provider, BodyTemplate: entry.BodyTemplate}); err != nil {
+				fail("", "%v", err)
+			}
+		}
+	}
+
+	return accountTypes
+}
+
+func (v *scheduleConfigValidator) validateEnvReferences(entry accountConfigEntry, fail func(field, format string, args ...any)) {
+	check := func(field, value string) {
+		for _, variable := range undefinedEnvReferences(value, v.lookupEnv) {
+			fail(field, "environment variable %s is not set", variable)
+		}
+	}
+
+	check(".host", entry.Host)
+	check(".handle", entry.Handle)
+	check(".token", entry.Token)
+	check(".url", entry.URL)
+
+	headerNames := make([]string, 0, len(entry.Headers))
+	for name := range entry.Headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		check(".headers."+name, entry.Headers[name])
+	}
+}
+
+func (v *scheduleConfigValidator) validateSchedules(entries []scheduleConfigEntry, accountTypes map[string]string) {
+	firstOriginByID := make(map[string]entryOrigin, len(entries))
+
+	for _, entry := range entries {
+		path := fmt.Sprintf("$.schedules[%d]", entry.origin.Index)
+		fail := func(field, format string, args ...any) {
+			v.report(SeverityError, entry.origin, entry.ID, path+field, format, args...)
+		}
+		warn := func(field, format string, args ...any) {
+			v.report(SeverityWarning, entry.origin, entry.ID, path+field, format, args...)
+		}
+
+		if entry.ID == "" {
+			fail(".id", "id is required")
+		} else if firstOrigin, exists := firstOriginByID[entry.ID]; exists {
+			fail(".id", "duplicate id, first defined at %s", describeOrigin(firstOrigin, "schedules"))
+		} else {
+			firstOriginByID[entry.ID] = entry.origin
+		}
+
+		if entry.Hour < 0 || entry.Hour > 23 {
+			fail(".hour", "hour must be between 0 and 23, got %d", entry.Hour)
+		}
+		if entry.Minute < 0 || entry.Minute > 59 {
+			fail(".minute", "minute must be between 0 and 59, got %d", entry.Minute)
+		}
+
+		switch entry.Type {
+		case "daily":
+		case "weekly":
+			if entry.DayOfWeek < 0 || entry.DayOfWeek > 6 {
+				fail(".dayOfWeek", "dayOfWeek must be between 0 (Sunday) and 6 (Saturday), got %d", entry.DayOfWeek)
+			}
+		case "monthly":
+			if entry.DayOfMonth < 1 || entry.DayOfMonth > 31 {
+				fail(".dayOfMonth", "dayOfMonth must be between 1 and 31, got %d", entry.DayOfMonth)
+			} else if entry.DayOfMonth > 28 {
+				warn(".dayOfMonth", "dayOfMonth %d does not exist in every month; shorter months post on their last day", entry.DayOfMonth)
+			}
+		case "yearly":
+			v.validateYearlyDate(entry, fail, warn)
+		default:
+			fail(".type", "unknown schedule type %q, expected one of daily, weekly, monthly, yearly", entry.Type)
+		}
+
+		if strings.TrimSpace(entry.Content) == "" && len(entry.Media) == 0 {
+			fail(".content", "content must not be empty")
+		}
+		accountType, accountExists := accountTypes[defaultString(entry.Account, DefaultAccountName)]
+		if !accountExists {
+			fail(".account", "unknown account %q", entry.Account)
+		}
+		validateVisibility(entry.Visibility, accountType, accountExists, fail)
+
+		for mediaIndex, mediaPath := range entry.Media {
+			mediaField := fmt.Sprintf(".media[%d]", mediaIndex)
+			if _, err := os.Stat(mediaPath); err != nil {
+				warn(mediaField, "media file is not readable: %v", err)
+			}
+		}
+		switch {
+		case !accountExists || accountType == "mastodon":
+		case accountType == "bluesky":
+			if entry.SpoilerText != "" {
+				fail(".spoilerText", "spoilerText is not supported by bluesky accounts")
+			}
+			if len(entry.Media) > 0 {
+				fail(".media", "media attachments are not supported by bluesky accounts")
+			}
+		case len(entry.Media) > 0:
+			warn(".media", "media attachments are only supported by mastodon accounts and will be ignored for %s", accountType)
+		}
+	}
+}
+
+func (v *scheduleConfigValidator) validateMuteWindows(entries []muteWindowEntry) {
+	for _, entry := range entries {
+		path := fmt.Sprintf("$.muteWindows[%d]", entry.origin.Index)
+		fail := func(field, format string, args ...any) {
+			v.report(SeverityError, entry.origin, "", path+field, format, args...)
+		}
+
+		_, _, startErr := parseTimeOfDay(entry.Start)
+		if startErr != nil {
+			fail(".start", "%v", startErr)
+		}
+		_, _, endErr := parseTimeOfDay(entry.End)
+		if endErr != nil {
+			fail(".end", "%v", endErr)
+		}
+		if startErr == nil && endErr == nil && entry.Start == entry.End {
+			fail(".end", "end must differ from start")
+		}
+		if entry.Policy != "" && !supportedMutePolicies[entry.Policy] {
+			fail(".policy", "unknown policy %q, expected skip or defer", entry.Policy)
+		}
+	}
+}
+
+func (v *scheduleConfigValidator) validateAlerts(entry *alertsEntry, files []string, accountTypes map[string]string) {
+	if entry == nil {
+		return
+	}
+	fail := func(field, format string, args ...any) {
+		v.report(SeverityError, entry.origin, "", "$.alerts"+field, format, args...)
+	}
+
+	if len(files) > 1 {
+		fail("", "alerts may only be defined once, also defined in %s", strings.Join(files[1:], ", "))
+	}
+	accountName := defaultString(entry.Account, DefaultAccountName)
+	accountType, accountExists := accountTypes[accountName]
+	if !accountExists {
+		fail(".account", "unknown account %q", accountName)
+	}
+	validateVisibility(entry.Visibility, accountType, accountExists, fail)
+	if entry.Threshold < 0 {
+		fail(".threshold", "threshold must be at least 1, got %d", entry.Threshold)
+	}
+	if _, err := parseRepeatAfter(entry.RepeatAfter); err != nil {
+		fail(".repeatAfter", "%v", err)
+	}
+	if accountExists && accountType == "misskey" && entry.To == "" && defaultString(entry.Visibility, DefaultAlertVisibility) == "specified" {
+		v.report(SeverityWarning, entry.origin, "", "$.alerts.to", "no recipient set; specified alerts will only be visible to the posting account")
+	}
+}
+
+func validateVisibility(visibility, accountType string, accountExists bool, fail func(field, format string, args ...any)) {
+	switch {
+	case visibility == "":
+	case !supportedVisibilities[visibility]:
+		fail(".visibility", "unknown visibility %q", visibility)
+	case !accountExists || accountVisibilities[accountType][visibility]:
+	case len(accountVisibilities[accountType]) == 0:
+		fail(".visibility", "%s accounts do not support visibility", accountType)
+	default:
+		fail(".visibility", "visibility %q is not supported by %s accounts, expected one of %s", visibility, accountType, strings.Join(sortedKeys(accountVisibilities[accountType]), ", "))
+	}
+}
+
+func (v *scheduleConfigValidator) validateYearlyDate(entry scheduleConfigEntry, fail, warn func(field, format string, args ...any)) {
+	if entry.Month < 1 || entry.Month > 12 {
+		fail(".month", "month must be between 1 and 12, got %d", entry.Month)
+		return
+	}
+
+	month := time.Month(entry.Month)
+	maxDay := time.Date(2024, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	switch {
+	case entry.DayOfMonth < 1 || entry.DayOfMonth > maxDay:
+		fail(".dayOfMonth", "dayOfMonth must be between 1 and %d for %s, got %d", maxDay, month, entry.DayOfMonth)
+	case month == time.February && entry.DayOfMonth == 29:
+		warn(".dayOfMonth", "February 29 only exists in leap years; other years post on February 28")
+	}
+}
+
+func describeOrigin(origin entryOrigin, section string) string {
+	return fmt.Sprintf("%s $.%s[%d]", origin.File, section, origin.Index)
+}
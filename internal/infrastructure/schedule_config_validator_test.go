@@ -0,0 +1,240 @@
+package infrastructure_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validateConfigJSON(t *testing.T, configJSON string) []infrastructure.ValidationIssue {
+	t.Helper()
+	filePath := createTempConfigFile(t, configJSON)
+	t.Cleanup(func() { os.Remove(filePath) })
+
+	issues, err := infrastructure.NewScheduleConfigLoader(filePath).Validate()
+	require.NoError(t, err)
+	return issues
+}
+
+func issuePaths(issues []infrastructure.ValidationIssue, severity infrastructure.ValidationSeverity) []string {
+	var paths []string
+	for _, issue := range issues {
+		if issue.Severity == severity {
+			paths = append(paths, issue.Path)
+		}
+	}
+	return paths
+}
+
+func TestScheduleConfigLoader_Validate_ValidConfig_ReturnsNoIssues(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "daily", "type": "daily", "hour": 23, "minute": 59, "content": "おやすみ"},
+			{"id": "weekly", "type": "weekly", "dayOfWeek": 6, "hour": 0, "minute": 0, "content": "週末"},
+			{"id": "yearly", "type": "yearly", "month": 12, "dayOfMonth": 31, "hour": 0, "minute": 0, "content": "大晦日"}
+		]
+	}`)
+
+	assert.Empty(t, issues)
+}
+
+func TestScheduleConfigLoader_Validate_ReportsEveryProblemWithPath(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 25, "minute": -3, "content": "x"},
+			{"id": "b", "type": "weekly", "dayOfWeek": 9, "hour": 9, "minute": 0, "content": "x"},
+			{"id": "a", "type": "hourly", "hour": 1, "minute": 0, "content": "x"},
+			{"id": "c", "type": "yearly", "month": 13, "dayOfMonth": 1, "hour": 0, "minute": 0, "content": "x"},
+			{"id": "d", "type": "daily", "hour": 1, "minute": 0, "content": "  "},
+			{"id": "", "type": "monthly", "dayOfMonth": 0, "hour": 1, "minute": 0, "content": "x"}
+		]
+	}`)
+
+	assert.ElementsMatch(t, []string{
+		"$.schedules[0].hour",
+		"$.schedules[0].minute",
+		"$.schedules[1].dayOfWeek",
+		"$.schedules[2].id",
+		"$.schedules[2].type",
+		"$.schedules[3].month",
+		"$.schedules[4].content",
+		"$.schedules[5].id",
+		"$.schedules[5].dayOfMonth",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_IssueIncludesIndexAndID(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "ok", "type": "daily", "hour": 1, "minute": 0, "content": "x"},
+			{"id": "late", "type": "daily", "hour": 24, "minute": 0, "content": "x"}
+		]
+	}`)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Index)
+	assert.Equal(t, "late", issues[0].ID)
+	assert.Equal(t, issues[0].File+": error: $.schedules[1].hour (id=late): hour must be between 0 and 23, got 24", issues[0].String())
+}
+
+func TestScheduleConfigLoader_Validate_WarnsOnClampedDays(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "monthly-31", "type": "monthly", "dayOfMonth": 31, "hour": 0, "minute": 0, "content": "月末"},
+			{"id": "leap-day", "type": "yearly", "month": 2, "dayOfMonth": 29, "hour": 0, "minute": 0, "content": "閏日"}
+		]
+	}`)
+
+	assert.Empty(t, issuePaths(issues, infrastructure.SeverityError))
+	assert.Equal(t, []string{"$.schedules[0].dayOfMonth", "$.schedules[1].dayOfMonth"}, issuePaths(issues, infrastructure.SeverityWarning))
+}
+
+func TestScheduleConfigLoader_Validate_YearlyDayMustExistInMonth(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "feb-30", "type": "yearly", "month": 2, "dayOfMonth": 30, "hour": 0, "minute": 0, "content": "x"}
+		]
+	}`)
+
+	assert.Equal(t, []string{"$.schedules[0].dayOfMonth"}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_ChecksAccountsAndReferences(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"accounts": [
+			{"name": "default", "type": "misskey", "host": "h", "token": "t"},
+			{"name": "mastodon", "type": "mastodon", "host": "h"},
+			{"name": "mastodon", "type": "mastodon", "host": "h", "token": "t"},
+			{"name": "bsky", "type": "bluesky", "token": "t"},
+			{"name": "hook", "type": "webhook", "url": "http://localhost", "provider": "teams"},
+			{"name": "fax", "type": "fax"}
+		],
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 1, "minute": 0, "content": "x", "account": "missing"},
+			{"id": "b", "type": "daily", "hour": 1, "minute": 0, "content": "x", "account": "mastodon", "visibility": "friends"}
+		]
+	}`)
+
+	assert.ElementsMatch(t, []string{
+		"$.accounts[0].name",
+		"$.accounts[1].token",
+		"$.accounts[2].name",
+		"$.accounts[3].handle",
+		"$.accounts[4]",
+		"$.accounts[5].type",
+		"$.schedules[0].account",
+		"$.schedules[1].visibility",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_ChecksVisibilityAgainstAccountType(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"accounts": [
+			{"name": "misskey", "type": "misskey", "host": "h", "token": "t", "visibility": "unlisted"},
+			{"name": "mastodon", "type": "mastodon", "host": "h", "token": "t", "visibility": "unlisted"},
+			{"name": "bsky", "type": "bluesky", "handle": "a.bsky.social", "token": "t", "visibility": "public"}
+		],
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 1, "minute": 0, "content": "x", "visibility": "direct"},
+			{"id": "b", "type": "daily", "hour": 1, "minute": 0, "content": "x", "account": "mastodon", "visibility": "direct"},
+			{"id": "c", "type": "daily", "hour": 1, "minute": 0, "content": "x", "account": "mastodon", "visibility": "followers"}
+		],
+		"alerts": {"account": "misskey", "visibility": "private"}
+	}`)
+
+	assert.Equal(t, []string{
+		"$.accounts[0].visibility",
+		"$.accounts[2].visibility",
+		"$.schedules[0].visibility",
+		"$.alerts.visibility",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_RejectsSpoilerTextAndMediaForBluesky(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"accounts": [{"name": "bsky", "type": "bluesky", "handle": "a.bsky.social", "token": "t"}],
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 1, "minute": 0, "content": "x", "account": "bsky", "spoilerText": "cw", "media": ["missing.png"]}
+		]
+	}`)
+
+	assert.Equal(t, []string{
+		"$.schedules[0].spoilerText",
+		"$.schedules[0].media",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_WarnsOnMediaForUnsupportedAccount(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 1, "minute": 0, "content": "x", "media": ["missing.png"]}
+		]
+	}`)
+
+	assert.Empty(t, issuePaths(issues, infrastructure.SeverityError))
+	assert.Equal(t, []string{"$.schedules[0].media[0]", "$.schedules[0].media"}, issuePaths(issues, infrastructure.SeverityWarning))
+}
+
+func TestScheduleConfigLoader_Load_InvalidConfig_ReturnsValidationError(t *testing.T) {
+	filePath := createTempConfigFile(t, `{
+		"schedules": [
+			{"id": "a", "type": "daily", "hour": 25, "minute": 0, "content": "x"},
+			{"id": "b", "type": "hourly", "hour": 1, "minute": 0, "content": "x"}
+		]
+	}`)
+	defer os.Remove(filePath)
+
+	_, err := infrastructure.NewScheduleConfigLoader(filePath).Load()
+
+	var validationError *infrastructure.ValidationError
+	require.True(t, errors.As(err, &validationError))
+	assert.Len(t, validationError.Issues, 2)
+	assert.Contains(t, err.Error(), "$.schedules[0].hour")
+	assert.Contains(t, err.Error(), "$.schedules[1].type")
+}
+
+func TestScheduleConfigLoader_Validate_ChecksMuteWindows(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}],
+		"muteWindows": [
+			{"start": "01:00", "end": "06:00", "policy": "defer"},
+			{"start": "25:00", "end": "6am"},
+			{"start": "03:00", "end": "03:00", "policy": "later"}
+		]
+	}`)
+
+	assert.Equal(t, []string{
+		"$.muteWindows[1].start",
+		"$.muteWindows[1].end",
+		"$.muteWindows[2].end",
+		"$.muteWindows[2].policy",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_ChecksAlerts(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}],
+		"alerts": {"account": "ops", "visibility": "secret", "threshold": -1, "repeatAfter": "soon"}
+	}`)
+
+	assert.Equal(t, []string{
+		"$.alerts.account",
+		"$.alerts.visibility",
+		"$.alerts.threshold",
+		"$.alerts.repeatAfter",
+	}, issuePaths(issues, infrastructure.SeverityError))
+}
+
+func TestScheduleConfigLoader_Validate_WarnsOnAlertsWithoutRecipient(t *testing.T) {
+	issues := validateConfigJSON(t, `{
+		"schedules": [{"id": "daily", "type": "daily", "hour": 12, "minute": 0, "content": "x"}],
+		"alerts": {"threshold": 2}
+	}`)
+
+	assert.Empty(t, issuePaths(issues, infrastructure.SeverityError))
+	assert.Equal(t, []string{"$.alerts.to"}, issuePaths(issues, infrastructure.SeverityWarning))
+}
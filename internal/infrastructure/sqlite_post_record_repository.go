@@ -0,0 +1,309 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	_ "modernc.org/sqlite"
+)
+
+var sqliteMigrations = []string{
+	`CREATE TABLE post_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		schedule_id TEXT NOT NULL,
+		account TEXT NOT NULL DEFAULT '',
+		posted_at TEXT NOT NULL,
+		posted_unix INTEGER NOT NULL,
+		note_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE INDEX post_history_schedule ON post_history (schedule_id, status, posted_unix);`,
+	`ALTER TABLE post_history ADD COLUMN latency_ms INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE post_history ADD COLUMN text_hash TEXT NOT NULL DEFAULT '';
+	CREATE INDEX post_history_posted ON post_history (posted_unix);`,
+	`ALTER TABLE post_history ADD COLUMN scheduled_at TEXT NOT NULL DEFAULT '';`,
+}
+
+const sqlitePostRecordColumns = "schedule_id, account, posted_at, note_id, status, error, latency_ms, text_hash, scheduled_at"
+
+var sqliteReadOnlyColumns = []string{
+	"",
+	"schedule_id, account, posted_at, note_id, status, error, 0, '', ''",
+	"schedule_id, account, posted_at, note_id, status, error, latency_ms, text_hash, ''",
+	sqlitePostRecordColumns,
+}
+
+type SQLitePostRecordRepository struct {
+	db      *sql.DB
+	columns string
+}
+
+func NewSQLitePostRecordRepository(filePath string) (*SQLitePostRecordRepository, error) {
+	db, err := sql.Open("sqlite", "file:"+filePath+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	repository := &SQLitePostRecordRepository{db: db, columns: sqlitePostRecordColumns}
+	if err := repository.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return repository, nil
+}
+
+func NewReadOnlySQLitePostRecordRepository(filePath string) (*SQLitePostRecordRepository, error) {
+	options := "mode=ro&_pragma=busy_timeout(5000)"
+	if _, err := os.Stat(filePath + "-wal"); errors.Is(err, os.ErrNotExist) {
+		options += "&immutable=1"
+	}
+	db, err := sql.Open("sqlite", "file:"+filePath+"?"+options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version >= len(sqliteReadOnlyColumns) {
+		db.Close()
+		return nil, fmt.Errorf("database schema version %d is newer than supported version %d", version, len(sqliteMigrations))
+	}
+	return &SQLitePostRecordRepository{db: db, columns: sqliteReadOnlyColumns[version]}, nil
+}
+
+func (r *SQLitePostRecordRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *SQLitePostRecordRepository) Flush() error {
+	if _, err := r.db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLitePostRecordRepository) migrate() error {
+	var version int
+	if err := r.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(sqliteMigrations))
+	}
+
+	for index := version; index < len(sqliteMigrations); index++ {
+		tx, err := r.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqliteMigrations[index]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", index+1, err)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", index+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", index+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", index+1, err)
+		}
+	}
+	return nil
+}
+
+func (r *SQLitePostRecordRepository) Find(scheduleID string) (domain.PostRecord, error) {
+	if r.columns == "" {
+		return domain.PostRecord{}, nil
+	}
+	row := r.db.QueryRow(
+		`SELECT `+r.columns+` FROM post_history
+		WHERE schedule_id = ? AND status = ? ORDER BY posted_unix DESC, id DESC LIMIT 1`,
+		scheduleID, domain.PostStatusPosted,
+	)
+
+	record, err := scanPostRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PostRecord{}, nil
+	}
+	return record, err
+}
+
+func (r *SQLitePostRecordRepository) Save(record domain.PostRecord) error {
+	return r.Append(record)
+}
+
+func (r *SQLitePostRecordRepository) Append(record domain.PostRecord) error {
+	return insertPostRecord(r.db, record)
+}
+
+func (r *SQLitePostRecordRepository) Query(query ports.PostHistoryQuery) ([]domain.PostRecord, error) {
+	if r.columns == "" {
+		return nil, nil
+	}
+	var conditions []string
+	var args []any
+	if query.ScheduleID != "" {
+		conditions = append(conditions, "schedule_id = ?")
+		args = append(args, query.ScheduleID)
+	}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "posted_unix >= ?")
+		args = append(args, query.Since.UnixNano())
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+
+	statement := "SELECT " + r.columns + " FROM post_history"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY posted_unix DESC, id DESC"
+	if query.Limit > 0 {
+		statement += fmt.Sprintf(" LIMIT %d", query.Limit)
+	}
+
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query post history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.PostRecord
+	for rows.Next() {
+		record, err := scanPostRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query post history: %w", err)
+	}
+
+	slices.Reverse(records)
+	return records, nil
+}
+
+func (r *SQLitePostRecordRepository) Prune(before time.Time) (int, error) {
+	result, err := r.db.Exec(
+		`DELETE FROM post_history WHERE posted_unix < ? AND id NOT IN (
+			SELECT MAX(id) FROM post_history WHERE status = ? GROUP BY schedule_id
+		)`,
+		before.UnixNano(), domain.PostStatusPosted,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune post history: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+func (r *SQLitePostRecordRepository) ImportJSON(jsonPath string) (int, error) {
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read post records: %w", err)
+	}
+	var store jsonRecordStore
+	if err := json.Unmarshal(data, &store); err != nil {
+		return 0, fmt.Errorf("failed to parse post records: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	imported := 0
+	for _, scheduleID := range sortedKeys(store.Records) {
+		record := store.Records[scheduleID]
+		var exists bool
+		err := tx.QueryRow(
+			"SELECT EXISTS (SELECT 1 FROM post_history WHERE schedule_id = ? AND posted_unix = ?)",
+			record.ScheduleID, record.LastPostedAt.UnixNano(),
+		).Scan(&exists)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			continue
+		}
+		postRecord := domain.NewPostRecord(record.ScheduleID, record.LastPostedAt)
+		if record.ScheduledAt != nil {
+			postRecord.ScheduledAt = *record.ScheduledAt
+		}
+		if err := insertPostRecord(tx, postRecord); err != nil {
+			return 0, err
+		}
+		imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+type sqlExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func insertPostRecord(db sqlExecer, record domain.PostRecord) error {
+	status := record.Status
+	if status == "" {
+		status = domain.PostStatusPosted
+	}
+	var scheduledAt string
+	if !record.ScheduledAt.IsZero() {
+		scheduledAt = record.ScheduledAt.Format(time.RFC3339Nano)
+	}
+
+	_, err := db.Exec(
+		`INSERT INTO post_history (schedule_id, account, posted_at, posted_unix, note_id, status, error, latency_ms, text_hash, scheduled_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		record.ScheduleID, record.Account, record.LastPostedAt.Format(time.RFC3339Nano), record.LastPostedAt.UnixNano(),
+		record.NoteID, status, record.Error, record.Latency.Milliseconds(), record.TextHash, scheduledAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save post record: %w", err)
+	}
+	return nil
+}
+
+func scanPostRecord(row interface{ Scan(dest ...any) error }) (domain.PostRecord, error) {
+	var record domain.PostRecord
+	var postedAt, scheduledAt string
+	var latencyMilliseconds int64
+	if err := row.Scan(&record.ScheduleID, &record.Account, &postedAt, &record.NoteID, &record.Status, &record.Error, &latencyMilliseconds, &record.TextHash, &scheduledAt); err != nil {
+		return domain.PostRecord{}, err
+	}
+	record.Latency = time.Duration(latencyMilliseconds) * time.Millisecond
+
+	lastPostedAt, err := time.Parse(time.RFC3339Nano, postedAt)
+	if err != nil {
+		return domain.PostRecord{}, fmt.Errorf("invalid posted_at %q: %w", postedAt, err)
+	}
+	record.LastPostedAt = lastPostedAt
+	if scheduledAt != "" {
+		if record.ScheduledAt, err = time.Parse(time.RFC3339Nano, scheduledAt); err != nil {
+			return domain.PostRecord{}, fmt.Errorf("invalid scheduled_at %q: %w", scheduledAt, err)
+		}
+	}
+	return record, nil
+}
@@ -0,0 +1,293 @@
+package infrastructure_test
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSQLiteRepository(t *testing.T, path string) *infrastructure.SQLitePostRecordRepository {
+	t.Helper()
+	repository, err := infrastructure.NewSQLitePostRecordRepository(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { repository.Close() })
+	return repository
+}
+
+func TestSQLitePostRecordRepository_Find_WithoutRecords_ReturnsZeroRecord(t *testing.T) {
+	repository := newTestSQLiteRepository(t, filepath.Join(t.TempDir(), "records.db"))
+
+	record, err := repository.Find("daily-hijiki")
+
+	require.NoError(t, err)
+	assert.True(t, record.IsZero())
+}
+
+func TestSQLitePostRecordRepository_Find_ReturnsLatestSuccessfulPost(t *testing.T) {
+	repository := newTestSQLiteRepository(t, filepath.Join(t.TempDir(), "records.db"))
+	jst := time.FixedZone("JST", 9*60*60)
+	first := domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 1, 12, 37, 0, 0, jst))
+	first.Account = "mastodon"
+	first.NoteID = "note-1"
+	second := domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 2, 12, 37, 0, 0, jst))
+	second.NoteID = "note-2"
+	failed := domain.NewFailedPostRecord("daily-hijiki", time.Date(2026, 2, 3, 12, 37, 0, 0, jst), errors.New("timeout"))
+	for _, record := range []domain.PostRecord{first, second, failed} {
+		require.NoError(t, repository.Save(record))
+	}
+
+	record, err := repository.Find("daily-hijiki")
+
+	require.NoError(t, err)
+	assert.Equal(t, "note-2", record.NoteID)
+	assert.Equal(t, domain.PostStatusPosted, record.Status)
+	assert.True(t, second.LastPostedAt.Equal(record.LastPostedAt))
+	_, offset := record.LastPostedAt.Zone()
+	assert.Equal(t, 9*60*60, offset)
+}
+
+func TestSQLitePostRecordRepository_StoresFullHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	repository := newTestSQLiteRepository(t, path)
+	posted := domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 1, 12, 37, 0, 0, time.UTC))
+	posted.Account = "mastodon"
+	posted.NoteID = "note-1"
+	require.NoError(t, repository.Save(posted))
+	require.NoError(t, repository.Save(domain.NewFailedPostRecord("daily-hijiki", time.Date(2026, 2, 2, 12, 37, 0, 0, time.UTC), errors.New("timeout"))))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+	rows, err := db.Query("SELECT schedule_id, account, note_id, status, error FROM post_history ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var history [][]string
+	for rows.Next() {
+		var scheduleID, account, noteID, status, message string
+		require.NoError(t, rows.Scan(&scheduleID, &account, &noteID, &status, &message))
+		history = append(history, []string{scheduleID, account, noteID, status, message})
+	}
+	assert.Equal(t, [][]string{
+		{"daily-hijiki", "mastodon", "note-1", "posted", ""},
+		{"daily-hijiki", "", "", "failed", "timeout"},
+	}, history)
+}
+
+func TestSQLitePostRecordRepository_Reopen_KeepsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	repository, err := infrastructure.NewSQLitePostRecordRepository(path)
+	require.NoError(t, err)
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 1, 12, 37, 0, 0, time.UTC))))
+	require.NoError(t, repository.Close())
+
+	record, err := newTestSQLiteRepository(t, path).Find("daily-hijiki")
+
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+}
+
+func TestSQLitePostRecordRepository_NewerSchemaVersion_ReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec("PRAGMA user_version = 99")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = infrastructure.NewSQLitePostRecordRepository(path)
+
+	require.Error(t, err)
+}
+
+func TestOpenReadOnlyPostRecordRepository_MissingDatabase_CreatesNoFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	repository, err := infrastructure.OpenReadOnlyPostRecordRepository(filepath.Join(dir, "records.db"))
+
+	require.NoError(t, err)
+	record, err := repository.Find("daily-hijiki")
+	require.NoError(t, err)
+	assert.True(t, record.LastPostedAt.IsZero())
+	assert.ErrorIs(t, repository.Save(domain.NewPostRecord("daily-hijiki", time.Now())), infrastructure.ErrReadOnlyRecords)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReadOnlySQLitePostRecordRepository_ReadsWithoutWriting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "records.db")
+	writable, err := infrastructure.NewSQLitePostRecordRepository(path)
+	require.NoError(t, err)
+	require.NoError(t, writable.Save(domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 1, 12, 37, 0, 0, time.UTC))))
+	require.NoError(t, writable.Flush())
+	require.NoError(t, writable.Close())
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	repository, err := infrastructure.NewReadOnlySQLitePostRecordRepository(path)
+	require.NoError(t, err)
+	record, err := repository.Find("daily-hijiki")
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+	assert.Error(t, repository.Save(domain.NewPostRecord("daily-hijiki", time.Date(2026, 2, 2, 12, 37, 0, 0, time.UTC))))
+	require.NoError(t, repository.Close())
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestReadOnlySQLitePostRecordRepository_OlderSchema_ReadsWithoutMigrating(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE post_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, schedule_id TEXT NOT NULL, account TEXT NOT NULL DEFAULT '',
+		posted_at TEXT NOT NULL, posted_unix INTEGER NOT NULL, note_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL, error TEXT NOT NULL DEFAULT '');
+		INSERT INTO post_history (schedule_id, posted_at, posted_unix, note_id, status)
+		VALUES ('daily-hijiki', '2026-02-01T12:37:00Z', 1769949420000000000, 'note-1', 'posted');
+		PRAGMA user_version = 1;`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	repository, err := infrastructure.NewReadOnlySQLitePostRecordRepository(path)
+	require.NoError(t, err)
+	defer repository.Close()
+	record, err := repository.Find("daily-hijiki")
+
+	require.NoError(t, err)
+	assert.Equal(t, "note-1", record.NoteID)
+	var version int
+	db, err = sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.QueryRow("PRAGMA user_version").Scan(&version))
+	assert.Equal(t, 1, version)
+}
+
+func TestSQLitePostRecordRepository_ImportJSON_SkipsAlreadyImportedRecords(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "post_records.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{
+		"records": {
+			"daily-hijiki": {"schedule_id": "daily-hijiki", "last_posted_at": "2026-02-01T12:37:00+09:00"},
+			"weekly-report": {"schedule_id": "weekly-report", "last_posted_at": "2026-02-02T09:00:00+09:00"}
+		}
+	}`), 0644))
+	repository := newTestSQLiteRepository(t, filepath.Join(dir, "records.db"))
+
+	imported, err := repository.ImportJSON(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported)
+
+	imported, err = repository.ImportJSON(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, imported)
+
+	record, err := repository.Find("weekly-report")
+	require.NoError(t, err)
+	assert.True(t, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC).Equal(record.LastPostedAt))
+}
+
+func TestSQLitePostRecordRepository_ImportJSON_KeepsScheduledTimeOfDeferredPost(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "post_records.json")
+	scheduledAt := time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)
+	postedAt := time.Date(2026, 2, 2, 6, 0, 0, 0, time.UTC)
+	record := domain.NewPostRecord("nightly", postedAt)
+	record.ScheduledAt = scheduledAt
+	require.NoError(t, infrastructure.NewJSONPostRecordRepository(jsonPath).Save(record))
+	repository := newTestSQLiteRepository(t, filepath.Join(dir, "records.db"))
+
+	imported, err := repository.ImportJSON(jsonPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, imported)
+
+	found, err := repository.Find("nightly")
+	require.NoError(t, err)
+	assert.True(t, postedAt.Equal(found.LastPostedAt))
+	assert.True(t, scheduledAt.Equal(found.ScheduledAt))
+}
+
+func TestOpenPostRecordRepository_ChoosesImplementationByExtension(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonRepository, err := infrastructure.OpenPostRecordRepository(filepath.Join(dir, "post_records.json"))
+	require.NoError(t, err)
+	assert.IsType(t, &infrastructure.JSONPostRecordRepository{}, jsonRepository)
+
+	sqliteRepository, err := infrastructure.OpenPostRecordRepository(filepath.Join(dir, "post_records.sqlite"))
+	require.NoError(t, err)
+	defer sqliteRepository.(*infrastructure.SQLitePostRecordRepository).Close()
+	assert.IsType(t, &infrastructure.SQLitePostRecordRepository{}, sqliteRepository)
+}
+
+func TestSQLitePostRecordRepository_Query_FiltersHistory(t *testing.T) {
+	repository := newTestSQLiteRepository(t, filepath.Join(t.TempDir(), "records.db"))
+	posted := domain.NewPostRecord("daily", time.Date(2026, 2, 1, 12, 37, 0, 0, time.UTC))
+	posted.Latency = 1500 * time.Millisecond
+	posted.TextHash = "hash"
+	failed := domain.NewFailedPostRecord("daily", time.Date(2026, 2, 2, 12, 37, 0, 0, time.UTC), errors.New("timeout"))
+	other := domain.NewPostRecord("weekly", time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC))
+	for _, record := range []domain.PostRecord{posted, failed, other} {
+		require.NoError(t, repository.Append(record))
+	}
+
+	all, err := repository.Query(ports.PostHistoryQuery{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, 1500*time.Millisecond, all[0].Latency)
+	assert.Equal(t, "hash", all[0].TextHash)
+
+	failures, err := repository.Query(ports.PostHistoryQuery{ScheduleID: "daily", Status: domain.PostStatusFailed})
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.Equal(t, "timeout", failures[0].Error)
+
+	recent, err := repository.Query(ports.PostHistoryQuery{Since: time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC), Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "weekly", recent[0].ScheduleID)
+}
+
+func TestSQLitePostRecordRepository_Prune_KeepsLatestPostOfEachSchedule(t *testing.T) {
+	repository := newTestSQLiteRepository(t, filepath.Join(t.TempDir(), "records.db"))
+	require.NoError(t, repository.Save(domain.NewPostRecord("yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))))
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))))
+	require.NoError(t, repository.Save(domain.NewPostRecord("daily", time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC))))
+
+	pruned, err := repository.Prune(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	record, err := repository.Find("yearly")
+	require.NoError(t, err)
+	assert.False(t, record.IsZero())
+}
+
+func TestSQLitePostRecordRepository_Find_KeepsScheduledTimeOfDeferredPost(t *testing.T) {
+	repository := newTestSQLiteRepository(t, filepath.Join(t.TempDir(), "records.db"))
+	record := domain.NewPostRecord("nightly", time.Date(2026, 2, 2, 6, 0, 0, 0, time.UTC))
+	record.ScheduledAt = time.Date(2026, 2, 1, 23, 30, 0, 0, time.UTC)
+	require.NoError(t, repository.Save(record))
+
+	found, err := repository.Find("nightly")
+
+	require.NoError(t, err)
+	assert.True(t, record.ScheduledAt.Equal(found.ScheduledAt))
+}
@@ -0,0 +1,50 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type StdoutPoster struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+func NewStdoutPoster(writer io.Writer) ports.Poster {
+	return &StdoutPoster{writer: writer}
+}
+
+func (p *StdoutPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	_, err := io.WriteString(p.writer, RenderPost(post))
+	return domain.PostResult{}, err
+}
+
+func RenderPost(post domain.Post) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "account: %s\n", defaultString(post.Account, DefaultAccountName))
+	fmt.Fprintf(&builder, "visibility: %s\n", defaultString(post.Visibility, "(account default)"))
+	if post.SpoilerText != "" {
+		fmt.Fprintf(&builder, "spoilerText: %s\n", post.SpoilerText)
+	}
+	if post.HasMedia() {
+		fmt.Fprintf(&builder, "media: %s\n", strings.Join(post.MediaPaths, ", "))
+	}
+	if post.IdempotencyKey != "" {
+		fmt.Fprintf(&builder, "idempotencyKey: %s\n", post.IdempotencyKey)
+	}
+	builder.WriteString("text:\n")
+	for _, line := range strings.Split(post.Text, "\n") {
+		fmt.Fprintf(&builder, "  %s\n", line)
+	}
+	builder.WriteString("---\n")
+	return builder.String()
+}
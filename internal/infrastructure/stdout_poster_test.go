@@ -0,0 +1,47 @@
+package infrastructure_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdoutPoster_Post_WritesRenderedRequest(t *testing.T) {
+	var output bytes.Buffer
+	poster := infrastructure.NewStdoutPoster(&output)
+
+	_, err := poster.Post(context.Background(), domain.Post{
+		Account:        "mastodon",
+		Text:           "人生のネタバレ\n「ひじき」",
+		SpoilerText:    "ネタバレ",
+		Visibility:     "public",
+		MediaPaths:     []string{"a.png", "b.png"},
+		IdempotencyKey: "daily-hijiki:2026-02-01",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "account: mastodon\n"+
+		"visibility: public\n"+
+		"spoilerText: ネタバレ\n"+
+		"media: a.png, b.png\n"+
+		"idempotencyKey: daily-hijiki:2026-02-01\n"+
+		"text:\n"+
+		"  人生のネタバレ\n"+
+		"  「ひじき」\n"+
+		"---\n", output.String())
+}
+
+func TestStdoutPoster_Post_WithoutAccount_ShowsDefaults(t *testing.T) {
+	var output bytes.Buffer
+	poster := infrastructure.NewStdoutPoster(&output)
+
+	_, err := poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "account: default\nvisibility: (account default)\ntext:\n  おひ\n---\n", output.String())
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SystemdNotifier struct {
+	socketPath string
+	watchdog   time.Duration
+}
+
+func NewSystemdNotifier() *SystemdNotifier {
+	notifier := &SystemdNotifier{socketPath: os.Getenv("NOTIFY_SOCKET")}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return notifier
+	}
+	if usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64); err == nil && usec > 0 {
+		notifier.watchdog = time.Duration(usec) * time.Microsecond
+	}
+	return notifier
+}
+
+func (n *SystemdNotifier) Enabled() bool {
+	return n.socketPath != ""
+}
+
+func (n *SystemdNotifier) WatchdogInterval() (time.Duration, bool) {
+	return n.watchdog, n.watchdog > 0
+}
+
+func (n *SystemdNotifier) Notify(states ...string) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	address := n.socketPath
+	if strings.HasPrefix(address, "@") {
+		address = "\x00" + address[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(strings.Join(states, "\n")))
+	return err
+}
@@ -0,0 +1,53 @@
+package infrastructure_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdNotifier_SendsStatesToNotifySocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "notify")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "notify.sock")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unix datagram sockets are not available: %v", err)
+	}
+	defer listener.Close()
+	t.Setenv("NOTIFY_SOCKET", socketPath)
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+
+	notifier := infrastructure.NewSystemdNotifier()
+	require.NoError(t, notifier.Notify("READY=1", "STATUS=Next: noon"))
+
+	buffer := make([]byte, 256)
+	n, err := listener.Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "READY=1\nSTATUS=Next: noon", string(buffer[:n]))
+	interval, enabled := notifier.WatchdogInterval()
+	assert.True(t, enabled)
+	assert.Equal(t, 30*time.Second, interval)
+}
+
+func TestSystemdNotifier_WithoutNotifySocket_DoesNothing(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	t.Setenv("WATCHDOG_PID", "1")
+
+	notifier := infrastructure.NewSystemdNotifier()
+
+	assert.False(t, notifier.Enabled())
+	assert.NoError(t, notifier.Notify("READY=1"))
+	_, enabled := notifier.WatchdogInterval()
+	assert.False(t, enabled)
+}
@@ -0,0 +1,102 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+const (
+	WebhookProviderDiscord = "discord"
+	WebhookProviderSlack   = "slack"
+	WebhookProviderGeneric = "generic"
+)
+
+var webhookBodyTemplates = map[string]string{
+	WebhookProviderDiscord: `{"content": {{json .Text}}}`,
+	WebhookProviderSlack:   `{"text": {{json .Text}}}`,
+	WebhookProviderGeneric: `{"text": {{json .Text}}, "spoilerText": {{json .SpoilerText}}, "visibility": {{json .Visibility}}, "account": {{json .Account}}}`,
+}
+
+type WebhookConfig struct {
+	URL          string
+	Provider     string
+	Headers      map[string]string
+	BodyTemplate string
+}
+
+type WebhookPoster struct {
+	url          string
+	headers      map[string]string
+	bodyTemplate *template.Template
+	httpClient   *http.Client
+}
+
+func NewWebhookPoster(config WebhookConfig) (ports.Poster, error) {
+	provider := defaultString(config.Provider, WebhookProviderGeneric)
+	templateText, exists := webhookBodyTemplates[provider]
+	if !exists {
+		return nil, fmt.Errorf("unknown webhook provider: %s", provider)
+	}
+	if config.BodyTemplate != "" {
+		templateText = config.BodyTemplate
+	}
+
+	bodyTemplate, err := template.New("webhook").Funcs(template.FuncMap{"json": marshalJSONString}).Parse(templateText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook body template: %w", err)
+	}
+
+	return &WebhookPoster{
+		url:          config.URL,
+		headers:      config.Headers,
+		bodyTemplate: bodyTemplate,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (p *WebhookPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	var body bytes.Buffer
+	if err := p.bodyTemplate.Execute(&body, post); err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to render webhook body: %w", err)
+	}
+	if !json.Valid(body.Bytes()) {
+		return domain.PostResult{}, fmt.Errorf("webhook body is not valid JSON: %s", body.String())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.url, &body)
+	if err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range p.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return domain.PostResult{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return domain.PostResult{}, fmt.Errorf("request failed with status code: %d", resp.StatusCode)
+	}
+
+	return domain.PostResult{}, nil
+}
+
+func marshalJSONString(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
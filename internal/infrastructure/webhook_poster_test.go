@@ -0,0 +1,111 @@
+package infrastructure_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookStandIn struct {
+	server     *httptest.Server
+	bodies     []string
+	headers    []http.Header
+	statusCode int
+}
+
+func newWebhookStandIn(t *testing.T, statusCode int) *webhookStandIn {
+	t.Helper()
+	standIn := &webhookStandIn{statusCode: statusCode}
+	standIn.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		standIn.bodies = append(standIn.bodies, string(body))
+		standIn.headers = append(standIn.headers, r.Header.Clone())
+		w.WriteHeader(standIn.statusCode)
+	}))
+	t.Cleanup(standIn.server.Close)
+	return standIn
+}
+
+func TestWebhookPoster_Post_DiscordPreset(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusNoContent)
+	poster, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: standIn.server.URL, Provider: "discord"})
+	require.NoError(t, err)
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost(`"おひ"`))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content": "\"おひ\""}`, standIn.bodies[0])
+}
+
+func TestWebhookPoster_Post_SlackPreset(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusOK)
+	poster, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: standIn.server.URL, Provider: "slack"})
+	require.NoError(t, err)
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "おひ"}`, standIn.bodies[0])
+}
+
+func TestWebhookPoster_Post_GenericTemplateAndHeaders(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusAccepted)
+	poster, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{
+		URL:          standIn.server.URL,
+		Headers:      map[string]string{"X-Api-Key": "secret"},
+		BodyTemplate: `{"message": {{json .Text}}, "warning": {{json .SpoilerText}}, "tags": ["hijiki"]}`,
+	})
+	require.NoError(t, err)
+
+	_, err = poster.Post(context.Background(), domain.Post{Text: "ひじき", SpoilerText: "ネタバレ"})
+
+	require.NoError(t, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(standIn.bodies[0]), &body))
+	assert.Equal(t, "ひじき", body["message"])
+	assert.Equal(t, "ネタバレ", body["warning"])
+	assert.Equal(t, "secret", standIn.headers[0].Get("X-Api-Key"))
+	assert.Equal(t, "application/json", standIn.headers[0].Get("Content-Type"))
+}
+
+func TestWebhookPoster_Post_WhenTemplateRendersInvalidJSON_ReturnsErrorWithoutRequest(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusOK)
+	poster, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: standIn.server.URL, BodyTemplate: `{"text": {{.Text}}}`})
+	require.NoError(t, err)
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.Error(t, err)
+	assert.Empty(t, standIn.bodies)
+}
+
+func TestWebhookPoster_Post_WhenServerFails_ReturnsError(t *testing.T) {
+	standIn := newWebhookStandIn(t, http.StatusInternalServerError)
+	poster, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: standIn.server.URL, Provider: "slack"})
+	require.NoError(t, err)
+
+	_, err = poster.Post(context.Background(), domain.NewTextPost("おひ"))
+
+	require.Error(t, err)
+}
+
+func TestNewWebhookPoster_UnknownProvider_ReturnsError(t *testing.T) {
+	_, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: "http://localhost", Provider: "teams"})
+
+	require.Error(t, err)
+}
+
+func TestNewWebhookPoster_InvalidTemplate_ReturnsError(t *testing.T) {
+	_, err := infrastructure.NewWebhookPoster(infrastructure.WebhookConfig{URL: "http://localhost", BodyTemplate: `{{.Text`})
+
+	require.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+)
+
+type Exporter struct {
+	registry   *Registry
+	scheduler  *scheduler.Scheduler
+	repository ports.PostRecordRepository
+	clock      ports.Clock
+	health     http.Handler
+}
+
+func NewExporter(registry *Registry, s *scheduler.Scheduler, repository ports.PostRecordRepository, clock ports.Clock) *Exporter {
+	return &Exporter{registry: registry, scheduler: s, repository: repository, clock: clock}
+}
+
+func (e *Exporter) SetHealthHandler(health http.Handler) {
+	e.health = health
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/healthz" && e.health != nil {
+		e.health.ServeHTTP(w, r)
+		return
+	}
+	if r.URL.Path != "/metrics" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body bytes.Buffer
+	e.writeMetrics(&body)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write(body.Bytes())
+}
+
+func (e *Exporter) writeMetrics(w io.Writer) {
+	e.registry.writePostMetrics(w)
+
+	now := e.clock.Now()
+	states := e.scheduler.States()
+
+	writeHeader(w, "hijiki_job_next_fire_timestamp_seconds", "Unix time of the next scheduled post.", "gauge")
+	for _, state := range states {
+		fmt.Fprintf(w, "hijiki_job_next_fire_timestamp_seconds{schedule=%s} %d\n", quote(state.Job.ID), state.NextTime.Unix())
+	}
+
+	writeHeader(w, "hijiki_job_last_success_age_seconds", "Seconds since the last successful post.", "gauge")
+	for _, state := range states {
+		record, err := e.repository.Find(state.Job.ID)
+		if err != nil || record.LastPostedAt.IsZero() {
+			continue
+		}
+		fmt.Fprintf(w, "hijiki_job_last_success_age_seconds{schedule=%s} %s\n", quote(state.Job.ID), formatFloat(now.Sub(record.LastPostedAt).Seconds()))
+	}
+
+	writeHeader(w, "hijiki_scheduler_loop_lag_seconds", "How late the scheduler loop woke up on its last timer.", "gauge")
+	fmt.Fprintf(w, "hijiki_scheduler_loop_lag_seconds %s\n", formatFloat(e.scheduler.LoopLag().Seconds()))
+}
+
+func (e *Exporter) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{Handler: e, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
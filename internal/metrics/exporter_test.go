@@ -0,0 +1,111 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+	"github.com/CAT5NEKO/hijikiTool/internal/metrics"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type SteppingClock struct {
+	currentTime time.Time
+	step        time.Duration
+}
+
+func (c *SteppingClock) Now() time.Time {
+	now := c.currentTime
+	c.currentTime = c.currentTime.Add(c.step)
+	return now
+}
+
+type FakePoster struct {
+	failFor string
+}
+
+func (p *FakePoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	if post.ScheduleID == p.failFor {
+		return domain.PostResult{}, assert.AnError
+	}
+	return domain.PostResult{ID: "note-1"}, nil
+}
+
+func scrape(t *testing.T, exporter http.Handler) string {
+	recorder := httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestExporter_ReportsPostCountersAndLatency(t *testing.T) {
+	clock := &SteppingClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC), step: 300 * time.Millisecond}
+	registry := metrics.NewRegistry()
+	repository := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	poster := metrics.NewPoster(&FakePoster{failFor: "evening"}, registry, clock)
+	s := scheduler.New(clock, repository, poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+		{ID: "evening", Schedule: domain.NewDailySchedule(18, 0), Content: "Evening", Account: "sub"},
+	})
+
+	require.NoError(t, s.Trigger(context.Background(), "noon", false))
+	assert.Error(t, s.Trigger(context.Background(), "evening", false))
+
+	body := scrape(t, metrics.NewExporter(registry, s, repository, clock))
+
+	assert.Contains(t, body, `hijiki_posts_attempted_total{schedule="noon",account="default"} 1`)
+	assert.Contains(t, body, `hijiki_posts_succeeded_total{schedule="noon",account="default"} 1`)
+	assert.Contains(t, body, `hijiki_posts_failed_total{schedule="evening",account="sub"} 1`)
+	assert.Contains(t, body, `hijiki_post_api_latency_seconds_bucket{account="default",le="0.25"} 0`)
+	assert.Contains(t, body, `hijiki_post_api_latency_seconds_bucket{account="default",le="0.5"} 1`)
+	assert.Contains(t, body, `hijiki_post_api_latency_seconds_count{account="sub"} 1`)
+	assert.Contains(t, body, "# TYPE hijiki_post_api_latency_seconds histogram")
+}
+
+func TestExporter_ReportsJobGauges(t *testing.T) {
+	clock := &SteppingClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	repository := infrastructure.NewJSONPostRecordRepository(filepath.Join(t.TempDir(), "post_records.json"))
+	require.NoError(t, repository.Save(domain.NewPostRecord("noon", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))))
+	s := scheduler.New(clock, repository, &FakePoster{}, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+		{ID: "evening", Schedule: domain.NewDailySchedule(18, 0), Content: "Evening"},
+	})
+
+	body := scrape(t, metrics.NewExporter(metrics.NewRegistry(), s, repository, clock))
+
+	assert.Contains(t, body, `hijiki_job_next_fire_timestamp_seconds{schedule="evening"} 1769968800`)
+	assert.Contains(t, body, `hijiki_job_last_success_age_seconds{schedule="noon"} 10800`)
+	assert.NotContains(t, body, `hijiki_job_last_success_age_seconds{schedule="evening"}`)
+	assert.Contains(t, body, "hijiki_scheduler_loop_lag_seconds 0")
+}
+
+func TestExporter_Healthz_DelegatesToHealthHandler(t *testing.T) {
+	clock := &SteppingClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	s := scheduler.New(clock, nil, &FakePoster{}, nil)
+	exporter := metrics.NewExporter(metrics.NewRegistry(), s, nil, clock)
+	exporter.SetHealthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	recorder := httptest.NewRecorder()
+
+	exporter.ServeHTTP(recorder, httptest.NewRequest("GET", "/healthz", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
+
+func TestExporter_UnknownPath_ReturnsNotFound(t *testing.T) {
+	clock := &SteppingClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	s := scheduler.New(clock, nil, &FakePoster{}, nil)
+	recorder := httptest.NewRecorder()
+
+	metrics.NewExporter(metrics.NewRegistry(), s, nil, clock).ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
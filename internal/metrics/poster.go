@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type instrumentedPoster struct {
+	next     ports.Poster
+	registry *Registry
+	clock    ports.Clock
+}
+
+func NewPoster(next ports.Poster, registry *Registry, clock ports.Clock) ports.Poster {
+	return &instrumentedPoster{next: next, registry: registry, clock: clock}
+}
+
+func (p *instrumentedPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	startedAt := p.clock.Now()
+	result, err := p.next.Post(ctx, post)
+	p.registry.ObservePost(post, p.clock.Now().Sub(startedAt), err)
+	return result, err
+}
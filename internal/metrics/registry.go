@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/infrastructure"
+)
+
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type postLabels struct {
+	schedule string
+	account  string
+}
+
+type postCounts struct {
+	attempted int
+	succeeded int
+	failed    int
+}
+
+type histogram struct {
+	buckets []int
+	count   int
+	sum     float64
+}
+
+type Registry struct {
+	posts   map[postLabels]*postCounts
+	latency map[string]*histogram
+	mutex   sync.Mutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{posts: make(map[postLabels]*postCounts), latency: make(map[string]*histogram)}
+}
+
+func (r *Registry) ObservePost(post domain.Post, latency time.Duration, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	account := accountLabel(post.Account)
+	labels := postLabels{schedule: post.ScheduleID, account: account}
+	counts, exists := r.posts[labels]
+	if !exists {
+		counts = &postCounts{}
+		r.posts[labels] = counts
+	}
+	counts.attempted++
+	if err != nil {
+		counts.failed++
+	} else {
+		counts.succeeded++
+	}
+
+	h, exists := r.latency[account]
+	if !exists {
+		h = &histogram{buckets: make([]int, len(latencyBuckets))}
+		r.latency[account] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (r *Registry) writePostMetrics(w io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	labels := make([]postLabels, 0, len(r.posts))
+	for label := range r.posts {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].schedule != labels[j].schedule {
+			return labels[i].schedule < labels[j].schedule
+		}
+		return labels[i].account < labels[j].account
+	})
+
+	counters := []struct {
+		name  string
+		help  string
+		value func(*postCounts) int
+	}{
+		{"hijiki_posts_attempted_total", "Posts attempted.", func(c *postCounts) int { return c.attempted }},
+		{"hijiki_posts_succeeded_total", "Posts that succeeded.", func(c *postCounts) int { return c.succeeded }},
+		{"hijiki_posts_failed_total", "Posts that failed.", func(c *postCounts) int { return c.failed }},
+	}
+	for _, counter := range counters {
+		writeHeader(w, counter.name, counter.help, "counter")
+		for _, label := range labels {
+			fmt.Fprintf(w, "%s{schedule=%s,account=%s} %d\n", counter.name, quote(label.schedule), quote(label.account), counter.value(r.posts[label]))
+		}
+	}
+
+	accounts := make([]string, 0, len(r.latency))
+	for account := range r.latency {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	writeHeader(w, "hijiki_post_api_latency_seconds", "Latency of the posting API.", "histogram")
+	for _, account := range accounts {
+		h := r.latency[account]
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(w, "hijiki_post_api_latency_seconds_bucket{account=%s,le=\"%s\"} %d\n", quote(account), formatFloat(bound), h.buckets[i])
+		}
+		fmt.Fprintf(w, "hijiki_post_api_latency_seconds_bucket{account=%s,le=\"+Inf\"} %d\n", quote(account), h.count)
+		fmt.Fprintf(w, "hijiki_post_api_latency_seconds_sum{account=%s} %s\n", quote(account), formatFloat(h.sum))
+		fmt.Fprintf(w, "hijiki_post_api_latency_seconds_count{account=%s} %d\n", quote(account), h.count)
+	}
+}
+
+func writeHeader(w io.Writer, name, help, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func accountLabel(account string) string {
+	if account == "" {
+		return infrastructure.DefaultAccountName
+	}
+	return account
+}
+
+func quote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func formatFloat(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+const maxAttempts = 100
+
+var ErrNotRunning = errors.New("scheduler is not running")
+
+type AttemptStatus string
+
+const (
+	AttemptQueued  AttemptStatus = "queued"
+	AttemptPosted  AttemptStatus = "posted"
+	AttemptSkipped AttemptStatus = "skipped"
+	AttemptFailed  AttemptStatus = "failed"
+)
+
+type Attempt struct {
+	ID          string
+	ScheduleID  string
+	Force       bool
+	Status      AttemptStatus
+	NoteID      string
+	Error       string
+	RequestedAt time.Time
+	FinishedAt  time.Time
+}
+
+type dispatcher struct {
+	ctx  context.Context
+	pool *workerPool
+}
+
+func (s *Scheduler) setDispatcher(d *dispatcher) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dispatcher = d
+}
+
+func (s *Scheduler) Dispatch(id string, force bool) (Attempt, error) {
+	job, exists := s.FindJob(id)
+	if !exists {
+		return Attempt{}, fmt.Errorf("%w: %s", ErrUnknownSchedule, id)
+	}
+	_, useCase := s.snapshot()
+	if !force {
+		if err := useCase.CheckSilenced(job.ID); err != nil {
+			return Attempt{}, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.dispatcher == nil {
+		return Attempt{}, ErrNotRunning
+	}
+	s.attemptSeq++
+	attempt := Attempt{
+		ID:          fmt.Sprintf("%s-%d", job.ID, s.attemptSeq),
+		ScheduleID:  job.ID,
+		Force:       force,
+		Status:      AttemptQueued,
+		RequestedAt: s.clock.Now(),
+	}
+	s.storeAttempt(attempt)
+
+	ctx := s.dispatcher.ctx
+	s.dispatcher.pool.submit(ctx, job.ID, func() {
+		s.runAttempt(ctx, useCase, job, attempt)
+	})
+	return attempt, nil
+}
+
+func (s *Scheduler) runAttempt(ctx context.Context, useCase *usecases.SchedulePostUseCase, job Job, attempt Attempt) {
+	postCtx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	var record domain.PostRecord
+	var err error
+	if attempt.Force {
+		record, err = useCase.ForceExecute(postCtx, job.ID, job.Schedule, job.Post())
+	} else {
+		record, err = useCase.Execute(postCtx, job.ID, job.Schedule, job.Post())
+	}
+
+	logger := s.jobLogger(job).With("attempt_id", attempt.ID)
+	switch {
+	case err == nil && record.ScheduleID == "":
+		attempt.Status = AttemptSkipped
+		attempt.Error = "already posted in the current period"
+	case err == nil:
+		attempt.Status = AttemptPosted
+		attempt.NoteID = record.NoteID
+		logger.Info("Posted on request", "note_id", record.NoteID, "latency", record.Latency)
+	case errors.Is(err, usecases.ErrPaused) || errors.Is(err, usecases.ErrMuted):
+		attempt.Status = AttemptSkipped
+		attempt.Error = err.Error()
+	default:
+		attempt.Status = AttemptFailed
+		attempt.Error = err.Error()
+		logger.Error("Requested post failed", "error", err)
+	}
+	attempt.FinishedAt = s.clock.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.storeAttempt(attempt)
+}
+
+func (s *Scheduler) storeAttempt(attempt Attempt) {
+	if _, exists := s.attempts[attempt.ID]; !exists {
+		s.attemptOrder = append(s.attemptOrder, attempt.ID)
+		if len(s.attemptOrder) > maxAttempts {
+			delete(s.attempts, s.attemptOrder[0])
+			s.attemptOrder = s.attemptOrder[1:]
+		}
+	}
+	s.attempts[attempt.ID] = attempt
+}
+
+func (s *Scheduler) FindAttempt(id string) (Attempt, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	attempt, exists := s.attempts[id]
+	return attempt, exists
+}
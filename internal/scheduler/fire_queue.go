@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"container/heap"
+	"time"
+)
+
+type fireEntry struct {
+	job         Job
+	fireTime    time.Time
+	deferred    bool
+	scheduledAt time.Time
+}
+
+func (e fireEntry) scheduledTime() time.Time {
+	if e.deferred {
+		return e.scheduledAt
+	}
+	return e.fireTime
+}
+
+type fireQueue []fireEntry
+
+func (q fireQueue) Len() int {
+	return len(q)
+}
+
+func (q fireQueue) Less(i, j int) bool {
+	if q[i].fireTime.Equal(q[j].fireTime) {
+		return q[i].job.ID < q[j].job.ID
+	}
+	return q[i].fireTime.Before(q[j].fireTime)
+}
+
+func (q fireQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *fireQueue) Push(x any) {
+	*q = append(*q, x.(fireEntry))
+}
+
+func (q *fireQueue) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+func newFireQueue(jobs []Job, from time.Time) *fireQueue {
+	queue := make(fireQueue, 0, len(jobs))
+	for _, job := range jobs {
+		queue = append(queue, fireEntry{job: job, fireTime: job.Schedule.NextTime(from)})
+	}
+	heap.Init(&queue)
+	return &queue
+}
+
+func (q *fireQueue) peek() (fireEntry, bool) {
+	if len(*q) == 0 {
+		return fireEntry{}, false
+	}
+	return (*q)[0], true
+}
+
+func (q *fireQueue) popDue(now time.Time) []fireEntry {
+	var due []fireEntry
+	for {
+		entry, ok := q.peek()
+		if !ok || entry.fireTime.After(now) {
+			return due
+		}
+		due = append(due, heap.Pop(q).(fireEntry))
+	}
+}
+
+func (q *fireQueue) rearm(entry fireEntry, now time.Time) {
+	from := entry.fireTime
+	if now.After(from) {
+		from = now
+	}
+	entry.fireTime = entry.job.Schedule.NextTime(from)
+	heap.Push(q, entry)
+}
+
+func (q *fireQueue) replaceDeferred(entries []fireEntry) {
+	kept := (*q)[:0]
+	for _, entry := range *q {
+		if !entry.deferred {
+			kept = append(kept, entry)
+		}
+	}
+	*q = append(kept, entries...)
+	heap.Init(q)
+}
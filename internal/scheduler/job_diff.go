@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+)
+
+type JobDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+func (d JobDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func (d JobDiff) String() string {
+	if d.IsEmpty() {
+		return "no changes"
+	}
+	parts := make([]string, 0, 3)
+	if len(d.Added) > 0 {
+		parts = append(parts, "added: "+strings.Join(d.Added, ", "))
+	}
+	if len(d.Removed) > 0 {
+		parts = append(parts, "removed: "+strings.Join(d.Removed, ", "))
+	}
+	if len(d.Changed) > 0 {
+		parts = append(parts, "changed: "+strings.Join(d.Changed, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func DiffJobs(oldJobs, newJobs []Job) JobDiff {
+	oldByID := make(map[string]Job, len(oldJobs))
+	for _, job := range oldJobs {
+		oldByID[job.ID] = job
+	}
+
+	var diff JobDiff
+	newIDs := make(map[string]bool, len(newJobs))
+	for _, newJob := range newJobs {
+		newIDs[newJob.ID] = true
+		oldJob, existed := oldByID[newJob.ID]
+		switch {
+		case !existed:
+			diff.Added = append(diff.Added, newJob.ID)
+		case !sameJob(oldJob, newJob):
+			diff.Changed = append(diff.Changed, newJob.ID)
+		}
+	}
+	for _, oldJob := range oldJobs {
+		if !newIDs[oldJob.ID] {
+			diff.Removed = append(diff.Removed, oldJob.ID)
+		}
+	}
+	return diff
+}
+
+func sameJob(a, b Job) bool {
+	return a.Schedule.String() == b.Schedule.String() && reflect.DeepEqual(a.Post(), b.Post())
+}
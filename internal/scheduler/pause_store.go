@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"sync"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+type memoryPauseStore struct {
+	state domain.PauseState
+	mutex sync.Mutex
+}
+
+func (s *memoryPauseStore) Load() (domain.PauseState, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.state, nil
+}
+
+func (s *memoryPauseStore) Save(state domain.PauseState) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.state = state
+	return nil
+}
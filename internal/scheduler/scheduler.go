@@ -2,7 +2,11 @@ package scheduler
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
@@ -11,18 +15,76 @@ import (
 )
 
 type Job struct {
-	ID       string
-	Schedule domain.Schedule
-	Content  string
+	Source      string
+	ID          string
+	Schedule    domain.Schedule
+	Content     string
+	Account     string
+	SpoilerText string
+	Visibility  string
+	MediaPaths  []string
+}
+
+func (j Job) Post() domain.Post {
+	return domain.Post{
+		Account:     j.Account,
+		Text:        j.Content,
+		SpoilerText: j.SpoilerText,
+		Visibility:  j.Visibility,
+		MediaPaths:  j.MediaPaths,
+	}
+}
+
+const (
+	maxTimerWait        = time.Minute
+	clockJumpThreshold  = 5 * time.Second
+	DefaultWorkers      = 4
+	DefaultJobTimeout   = 2 * time.Minute
+	DefaultDrainTimeout = 30 * time.Second
+)
+
+var ErrUnknownSchedule = errors.New("unknown schedule")
+
+type JobState struct {
+	Job           Job
+	Paused        bool
+	NextTime      time.Time
+	DeferredUntil time.Time
+}
+
+type deferral struct {
+	until       time.Time
+	scheduledAt time.Time
 }
 
 type Scheduler struct {
-	clock      ports.Clock
-	repository ports.PostRecordRepository
-	poster     ports.Poster
-	jobs       []Job
-	useCase    *usecases.SchedulePostUseCase
-	tolerance  time.Duration
+	clock            ports.Clock
+	repository       ports.PostRecordRepository
+	poster           ports.Poster
+	jobs             []Job
+	useCase          *usecases.SchedulePostUseCase
+	tolerance        time.Duration
+	workers          int
+	jobTimeout       time.Duration
+	drainTimeout     time.Duration
+	pauses           ports.PauseStore
+	mutes            []domain.MuteWindow
+	deferred         map[string]deferral
+	locks            *usecases.ScheduleLocks
+	failures         map[string]int
+	alerts           *usecases.FailureAlertUseCase
+	dispatcher       *dispatcher
+	attempts         map[string]Attempt
+	attemptOrder     []string
+	attemptSeq       int
+	mutex            sync.RWMutex
+	reloaded         chan struct{}
+	deferralsChanged chan struct{}
+	loopLag          atomic.Int64
+	lastHeartbeat    atomic.Int64
+	heartbeat        time.Duration
+	log              *slog.Logger
+	onHeartbeat      func(time.Time)
 }
 
 func New(
@@ -31,51 +93,438 @@ func New(
 	poster ports.Poster,
 	jobs []Job,
 ) *Scheduler {
-	guard := domain.NewPostGuard()
-	useCase := usecases.NewSchedulePostUseCase(clock, repository, poster, guard)
+	s := &Scheduler{
+		clock:            clock,
+		repository:       repository,
+		poster:           poster,
+		jobs:             jobs,
+		tolerance:        time.Minute,
+		workers:          DefaultWorkers,
+		jobTimeout:       DefaultJobTimeout,
+		drainTimeout:     DefaultDrainTimeout,
+		pauses:           &memoryPauseStore{},
+		deferred:         make(map[string]deferral),
+		locks:            usecases.NewScheduleLocks(),
+		failures:         make(map[string]int),
+		attempts:         make(map[string]Attempt),
+		reloaded:         make(chan struct{}, 1),
+		deferralsChanged: make(chan struct{}, 1),
+	}
+	s.useCase = s.newUseCase()
+	return s
+}
+
+func (s *Scheduler) newUseCase() *usecases.SchedulePostUseCase {
+	useCase := usecases.NewSchedulePostUseCase(s.clock, s.repository, s.poster, domain.NewPostGuard())
+	useCase.SetScheduleLocks(s.locks)
+	useCase.SetPauseStore(s.pauses)
+	useCase.SetMuteWindows(s.mutes)
+	return useCase
+}
+
+func (s *Scheduler) SetPauseStore(pauses ports.PauseStore) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pauses = pauses
+	s.useCase = s.newUseCase()
+}
 
-	return &Scheduler{
-		clock:      clock,
-		repository: repository,
-		poster:     poster,
-		jobs:       jobs,
-		useCase:    useCase,
-		tolerance:  time.Minute,
+func (s *Scheduler) SetMuteWindows(windows []domain.MuteWindow) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.mutes = windows
+	s.useCase = s.newUseCase()
+}
+
+func (s *Scheduler) SetHeartbeat(interval time.Duration, onHeartbeat func(time.Time)) {
+	s.heartbeat = interval
+	s.onHeartbeat = onHeartbeat
+}
+
+func (s *Scheduler) SetLogger(logger *slog.Logger) {
+	s.log = logger
+}
+
+func (s *Scheduler) logger() *slog.Logger {
+	if s.log == nil {
+		return slog.Default()
 	}
+	return s.log
+}
+
+func (s *Scheduler) SetFailureAlerts(alerts *usecases.FailureAlertUseCase) {
+	s.alerts = alerts
 }
 
-func (s *Scheduler) Run(ctx context.Context) {
+func (s *Scheduler) SetWorkers(workers int) {
+	s.workers = workers
+}
+
+func (s *Scheduler) SetJobTimeout(timeout time.Duration) {
+	s.jobTimeout = timeout
+}
+
+func (s *Scheduler) SetDrainTimeout(timeout time.Duration) {
+	s.drainTimeout = timeout
+}
+
+func (s *Scheduler) Run(ctx context.Context, timers ports.TimerSource) {
+	postCtx, cancelPosts := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelPosts()
+
+	pool := newWorkerPool(s.workers)
+	defer s.drain(pool, cancelPosts, timers)
+	s.setDispatcher(&dispatcher{ctx: postCtx, pool: pool})
+	defer s.setDispatcher(nil)
+
+	queue := s.armTimers(s.clock.Now())
 	for {
-		s.RunOnce()
+		now := s.clock.Now()
+		s.beat(now)
+		for _, entry := range queue.popDue(now) {
+			s.fire(postCtx, pool, entry, now)
+			if entry.deferred {
+				s.clearDeferred(entry)
+				continue
+			}
+			queue.rearm(entry, now)
+		}
 
-		sleepDuration := s.NextWakeUpDuration()
-		if sleepDuration < s.tolerance {
-			sleepDuration = s.tolerance
+		wait := maxTimerWait
+		if s.heartbeat > 0 && s.heartbeat < wait {
+			wait = s.heartbeat
+		}
+		if entry, ok := queue.peek(); ok && entry.fireTime.Sub(now) < wait {
+			wait = entry.fireTime.Sub(now)
 		}
 
+		timer := timers.NewTimer(wait)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-time.After(sleepDuration):
+		case <-s.reloaded:
+			timer.Stop()
+			queue = s.armTimers(s.clock.Now())
+		case <-s.deferralsChanged:
+			timer.Stop()
+			queue.replaceDeferred(s.deferredEntries())
+		case <-timer.C():
+			woke := s.clock.Now()
+			drift := wallClockDrift(now.Add(wait), woke)
+			if drift > clockJumpThreshold || drift < -clockJumpThreshold {
+				s.logger().Warn("Wall clock jumped, re-arming timers", "drift", drift.Round(time.Second))
+				queue = s.armTimers(woke)
+				continue
+			}
+			s.loopLag.Store(int64(max(drift, 0)))
 		}
 	}
 }
 
-func (s *Scheduler) RunOnce() {
+func wallClockDrift(expected, actual time.Time) time.Duration {
+	return time.Duration(actual.UnixNano() - expected.UnixNano())
+}
+
+func (s *Scheduler) beat(now time.Time) {
+	s.lastHeartbeat.Store(now.UnixNano())
+	if s.onHeartbeat != nil {
+		s.onHeartbeat(now)
+	}
+}
+
+func (s *Scheduler) HeartbeatAge() (time.Duration, bool) {
+	last := s.lastHeartbeat.Load()
+	if last == 0 {
+		return 0, false
+	}
+	return s.clock.Now().Sub(time.Unix(0, last)), true
+}
+
+func (s *Scheduler) LoopLag() time.Duration {
+	return time.Duration(s.loopLag.Load())
+}
+
+func (s *Scheduler) drain(pool *workerPool, cancelPosts context.CancelFunc, timers ports.TimerSource) {
+	inFlight := pool.names()
+	if len(inFlight) == 0 {
+		return
+	}
+
+	s.logger().Info("Waiting for in-flight posts", "timeout", s.drainTimeout, "schedule_ids", inFlight)
+	timer := timers.NewTimer(s.drainTimeout)
+	defer timer.Stop()
+	if pool.waitUntil(timer.C()) {
+		s.logger().Info("All in-flight posts finished")
+		return
+	}
+
+	interrupted := pool.names()
+	cancelPosts()
+	pool.wait()
+	s.logger().Warn("Interrupted in-flight posts", "timeout", s.drainTimeout, "schedule_ids", interrupted)
+}
+
+func (s *Scheduler) armTimers(now time.Time) *fireQueue {
+	jobs, _ := s.snapshot()
+	queue := newFireQueue(jobs, now.Add(-s.tolerance))
+	queue.replaceDeferred(s.deferredEntries())
+	return queue
+}
+
+func (s *Scheduler) deferJob(job Job, scheduledAt, until time.Time) {
+	s.mutex.Lock()
+	s.deferred[job.ID] = deferral{until: until, scheduledAt: scheduledAt}
+	s.mutex.Unlock()
+
+	select {
+	case s.deferralsChanged <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) clearDeferred(entry fireEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if deferral, exists := s.deferred[entry.job.ID]; exists && deferral.until.Equal(entry.fireTime) {
+		delete(s.deferred, entry.job.ID)
+	}
+}
+
+func (s *Scheduler) deferredEntries() []fireEntry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	entries := make([]fireEntry, 0, len(s.deferred))
 	for _, job := range s.jobs {
-		if s.useCase.ShouldExecuteNow(job.ID, job.Schedule, s.tolerance) {
-			if err := s.useCase.Execute(job.ID, job.Schedule, job.Content); err != nil {
-				log.Printf("Failed to execute job %s: %v", job.ID, err)
+		if deferral, exists := s.deferred[job.ID]; exists {
+			entries = append(entries, fireEntry{job: job, fireTime: deferral.until, deferred: true, scheduledAt: deferral.scheduledAt})
+		}
+	}
+	return entries
+}
+
+func (s *Scheduler) fire(ctx context.Context, pool *workerPool, entry fireEntry, now time.Time) {
+	if late := now.Sub(entry.fireTime); late > s.tolerance {
+		s.jobLogger(entry.job).Warn("Skipped late job", "scheduled_at", entry.fireTime, "late", late.Round(time.Second))
+		return
+	}
+
+	_, useCase := s.snapshot()
+	pool.submit(ctx, entry.job.ID, func() {
+		s.execute(ctx, useCase, entry)
+	})
+}
+
+func (s *Scheduler) execute(ctx context.Context, useCase *usecases.SchedulePostUseCase, entry fireEntry) {
+	postCtx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	job := entry.job
+	var record domain.PostRecord
+	var err error
+	if entry.deferred {
+		record, err = useCase.ExecuteDeferred(postCtx, job.ID, job.Schedule, job.Post(), entry.scheduledAt)
+	} else {
+		record, err = useCase.Execute(postCtx, job.ID, job.Schedule, job.Post())
+	}
+	logger := s.jobLogger(job)
+	var muted *usecases.MutedError
+	switch {
+	case err == nil && record.ScheduleID == "":
+	case err == nil:
+		logger.Info("Posted", "note_id", record.NoteID, "attempt", s.recordAttempt(job.ID, false), "latency", record.Latency)
+		s.alert(ctx, logger, func(alertCtx context.Context) error {
+			return s.alerts.RecordSuccess(alertCtx, job.Account)
+		})
+	case errors.As(err, &muted) && muted.Window.Policy == domain.MutePolicyDefer:
+		logger.Info("Deferred job", "until", muted.Until, "reason", err.Error())
+		s.deferJob(job, entry.scheduledTime(), muted.Until)
+	case errors.Is(err, usecases.ErrPaused) || errors.Is(err, usecases.ErrMuted):
+		logger.Info("Skipped job", "reason", err.Error())
+	case record.Failed():
+		attempt := s.recordAttempt(job.ID, true)
+		logger.Error("Post failed", "attempt", attempt, "latency", record.Latency, "error", err)
+		s.alert(ctx, logger, func(alertCtx context.Context) error {
+			return s.alerts.RecordFailure(alertCtx, job.Account, job.ID, err)
+		})
+	default:
+		logger.Error("Failed to execute job", "error", err)
+	}
+}
+
+func (s *Scheduler) recordAttempt(id string, failed bool) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	attempt := s.failures[id] + 1
+	if failed {
+		s.failures[id] = attempt
+	} else {
+		delete(s.failures, id)
+	}
+	return attempt
+}
+
+func (s *Scheduler) alert(ctx context.Context, logger *slog.Logger, send func(context.Context) error) {
+	if s.alerts == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	if err := send(ctx); err != nil {
+		logger.Error("Failed to send alert", "error", err)
+	}
+}
+
+func (s *Scheduler) jobLogger(job Job) *slog.Logger {
+	logger := s.logger().With("schedule_id", job.ID)
+	if job.Account != "" {
+		logger = logger.With("account", job.Account)
+	}
+	return logger
+}
+
+func (s *Scheduler) RunOnce(ctx context.Context) {
+	pool := newWorkerPool(s.workers)
+	jobs, useCase := s.snapshot()
+	for _, job := range jobs {
+		if useCase.ShouldExecuteNow(job.ID, job.Schedule, s.tolerance) {
+			pool.submit(ctx, job.ID, func() {
+				s.execute(ctx, useCase, fireEntry{job: job, fireTime: s.clock.Now()})
+			})
+		}
+	}
+	pool.wait()
+}
+
+func (s *Scheduler) Reload(jobs []Job, poster ports.Poster) JobDiff {
+	s.mutex.Lock()
+	diff := DiffJobs(s.jobs, jobs)
+	s.jobs = jobs
+	s.poster = poster
+	s.useCase = s.newUseCase()
+	s.mutex.Unlock()
+
+	select {
+	case s.reloaded <- struct{}{}:
+	default:
+	}
+	return diff
+}
+
+func (s *Scheduler) snapshot() ([]Job, *usecases.SchedulePostUseCase) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.jobs, s.useCase
+}
+
+func (s *Scheduler) Jobs() []Job {
+	jobs, _ := s.snapshot()
+	return jobs
+}
+
+func (s *Scheduler) FindJob(id string) (Job, bool) {
+	for _, job := range s.Jobs() {
+		if job.ID == id {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+func (s *Scheduler) Pause(id string) error {
+	return s.setPaused(id, true)
+}
+
+func (s *Scheduler) Resume(id string) error {
+	return s.setPaused(id, false)
+}
+
+func (s *Scheduler) setPaused(id string, paused bool) error {
+	if _, exists := s.FindJob(id); !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownSchedule, id)
+	}
+	return s.updatePauseState(func(state domain.PauseState) domain.PauseState {
+		return state.WithSchedule(id, paused)
+	})
+}
+
+func (s *Scheduler) PauseAll() error {
+	return s.updatePauseState(func(state domain.PauseState) domain.PauseState {
+		return state.WithAll(true)
+	})
+}
+
+func (s *Scheduler) ResumeAll() error {
+	return s.updatePauseState(func(state domain.PauseState) domain.PauseState {
+		return state.WithAll(false)
+	})
+}
+
+func (s *Scheduler) updatePauseState(update func(domain.PauseState) domain.PauseState) error {
+	pauses := s.pauseStore()
+	state, err := pauses.Load()
+	if err != nil {
+		return err
+	}
+	return pauses.Save(update(state))
+}
+
+func (s *Scheduler) PauseState() (domain.PauseState, error) {
+	return s.pauseStore().Load()
+}
+
+func (s *Scheduler) IsPaused(id string) bool {
+	state, err := s.PauseState()
+	return err == nil && state.IsPaused(id)
+}
+
+func (s *Scheduler) pauseStore() ports.PauseStore {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.pauses
+}
+
+func (s *Scheduler) States() []JobState {
+	now := s.clock.Now()
+	state, _ := s.PauseState()
+	deferred := s.deferredEntries()
+	jobs := s.Jobs()
+	states := make([]JobState, 0, len(jobs))
+	for _, job := range jobs {
+		jobState := JobState{Job: job, Paused: state.IsPaused(job.ID), NextTime: job.Schedule.NextTime(now)}
+		for _, entry := range deferred {
+			if entry.job.ID == job.ID {
+				jobState.DeferredUntil = entry.fireTime
 			}
 		}
+		states = append(states, jobState)
+	}
+	return states
+}
+
+func (s *Scheduler) Trigger(ctx context.Context, id string, force bool) error {
+	job, exists := s.FindJob(id)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownSchedule, id)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.jobTimeout)
+	defer cancel()
+
+	_, useCase := s.snapshot()
+	if force {
+		_, err := useCase.ForceExecute(ctx, job.ID, job.Schedule, job.Post())
+		return err
 	}
+	_, err := useCase.Execute(ctx, job.ID, job.Schedule, job.Post())
+	return err
 }
 
 func (s *Scheduler) NextWakeUpDuration() time.Duration {
 	now := s.clock.Now()
 	var minDuration time.Duration
 
-	for i, job := range s.jobs {
+	for i, job := range s.Jobs() {
 		duration := job.Schedule.DurationUntil(now)
 		if i == 0 || duration < minDuration {
 			minDuration = duration
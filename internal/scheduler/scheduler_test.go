@@ -1,14 +1,21 @@
 package scheduler_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/application/usecases"
 	"github.com/CAT5NEKO/hijikiTool/internal/domain"
 	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type FakeClock struct {
@@ -28,8 +35,110 @@ func (c *FakeClock) Advance(d time.Duration) {
 	c.currentTime = c.currentTime.Add(d)
 }
 
+type FakeTimerSource struct {
+	clock  *FakeClock
+	armed  chan time.Duration
+	timers []*FakeTimer
+	mutex  sync.Mutex
+}
+
+func NewFakeTimerSource(clock *FakeClock) *FakeTimerSource {
+	return &FakeTimerSource{clock: clock, armed: make(chan time.Duration, 16)}
+}
+
+func (s *FakeTimerSource) NewTimer(d time.Duration) ports.Timer {
+	s.mutex.Lock()
+	timer := &FakeTimer{deadline: s.clock.Now().Add(d), channel: make(chan time.Time, 1)}
+	s.timers = append(s.timers, timer)
+	s.mutex.Unlock()
+	s.armed <- d
+	return timer
+}
+
+func (s *FakeTimerSource) Advance(d time.Duration) {
+	s.clock.Advance(d)
+	now := s.clock.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	pending := s.timers[:0]
+	for _, timer := range s.timers {
+		if timer.fire(now) {
+			continue
+		}
+		pending = append(pending, timer)
+	}
+	s.timers = pending
+}
+
+func (s *FakeTimerSource) NextArmed(t *testing.T) time.Duration {
+	select {
+	case d := <-s.armed:
+		return d
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not arm a timer")
+		return 0
+	}
+}
+
+type FakeTimer struct {
+	deadline time.Time
+	channel  chan time.Time
+	stopped  bool
+	mutex    sync.Mutex
+}
+
+func (t *FakeTimer) C() <-chan time.Time {
+	return t.channel
+}
+
+func (t *FakeTimer) Stop() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+func (t *FakeTimer) fire(now time.Time) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.stopped {
+		return true
+	}
+	if now.Before(t.deadline) {
+		return false
+	}
+	t.stopped = true
+	t.channel <- now
+	return true
+}
+
+func runScheduler(t *testing.T, s *scheduler.Scheduler, timers ports.TimerSource) {
+	cancel, done := startScheduler(s, timers)
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func waitForPostCount(t *testing.T, poster *FakePoster, expected int) {
+	assert.Eventually(t, func() bool { return poster.GetPostCount() == expected }, time.Second, time.Millisecond)
+}
+
+func startScheduler(s *scheduler.Scheduler, timers ports.TimerSource) (context.CancelFunc, <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, timers)
+		close(done)
+	}()
+	return cancel, done
+}
+
 type FakePostRecordRepository struct {
 	records map[string]domain.PostRecord
+	history []domain.PostRecord
 	mutex   sync.RWMutex
 }
 
@@ -54,16 +163,39 @@ func (r *FakePostRecordRepository) Save(record domain.PostRecord) error {
 	return nil
 }
 
+func (r *FakePostRecordRepository) Append(record domain.PostRecord) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.history = append(r.history, record)
+	return nil
+}
+
+func (r *FakePostRecordRepository) Query(query ports.PostHistoryQuery) ([]domain.PostRecord, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	var records []domain.PostRecord
+	for _, record := range r.history {
+		if query.ScheduleID == "" || record.ScheduleID == query.ScheduleID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *FakePostRecordRepository) Prune(before time.Time) (int, error) {
+	return 0, nil
+}
+
 type FakePoster struct {
 	postCount int
 	mutex     sync.Mutex
 }
 
-func (p *FakePoster) Post(content string) error {
+func (p *FakePoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 	p.postCount++
-	return nil
+	return domain.PostResult{}, nil
 }
 
 func (p *FakePoster) GetPostCount() int {
@@ -84,7 +216,7 @@ func TestScheduler_RunOnce_ExecutesScheduledPost(t *testing.T) {
 	}
 
 	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
-	s.RunOnce()
+	s.RunOnce(context.Background())
 
 	assert.Equal(t, 1, poster.GetPostCount())
 }
@@ -101,7 +233,7 @@ func TestScheduler_RunOnce_DoesNotExecuteWhenNotTime(t *testing.T) {
 	}
 
 	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
-	s.RunOnce()
+	s.RunOnce(context.Background())
 
 	assert.Equal(t, 0, poster.GetPostCount())
 }
@@ -118,8 +250,8 @@ func TestScheduler_RunOnce_DoesNotPostTwiceInSamePeriod(t *testing.T) {
 	}
 
 	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
-	s.RunOnce()
-	s.RunOnce()
+	s.RunOnce(context.Background())
+	s.RunOnce(context.Background())
 
 	assert.Equal(t, 1, poster.GetPostCount())
 }
@@ -180,7 +312,7 @@ func TestScheduler_RunOnce_DoesNotPostOnStartupWhenNotScheduledTime(t *testing.T
 	}
 
 	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
-	s.RunOnce()
+	s.RunOnce(context.Background())
 
 	assert.Equal(t, 0, poster.GetPostCount())
 }
@@ -198,14 +330,565 @@ func TestScheduler_RunOnce_PostsOnlyAtScheduledTime(t *testing.T) {
 
 	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
 
-	s.RunOnce()
+	s.RunOnce(context.Background())
 	assert.Equal(t, 0, poster.GetPostCount())
 
 	clock.currentTime = time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)
-	s.RunOnce()
+	s.RunOnce(context.Background())
 	assert.Equal(t, 1, poster.GetPostCount())
 
 	clock.currentTime = time.Date(2026, 2, 1, 8, 30, 0, 0, time.UTC)
-	s.RunOnce()
+	s.RunOnce(context.Background())
+	assert.Equal(t, 1, poster.GetPostCount())
+}
+
+func TestScheduler_Trigger_PostsImmediatelyOutsideScheduledTime(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &FakePoster{}
+	job := scheduler.Job{ID: "morning-post", Schedule: domain.NewDailySchedule(8, 0), Content: "Good morning!"}
+	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
+
+	err := s.Trigger(context.Background(), "morning-post", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, poster.GetPostCount())
+}
+
+func TestScheduler_Trigger_RespectsPostGuardUnlessForced(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	repo.Save(domain.NewPostRecord("morning-post", time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)))
+	poster := &FakePoster{}
+	job := scheduler.Job{ID: "morning-post", Schedule: domain.NewDailySchedule(8, 0), Content: "Good morning!"}
+	s := scheduler.New(clock, repo, poster, []scheduler.Job{job})
+
+	assert.NoError(t, s.Trigger(context.Background(), "morning-post", false))
+	assert.Equal(t, 0, poster.GetPostCount())
+
+	assert.NoError(t, s.Trigger(context.Background(), "morning-post", true))
+	assert.Equal(t, 1, poster.GetPostCount())
+}
+
+func TestScheduler_Trigger_UnknownSchedule_ReturnsError(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), &FakePoster{}, nil)
+
+	err := s.Trigger(context.Background(), "missing", false)
+
+	assert.Error(t, err)
+}
+
+func TestUpcoming_ReturnsNextFireTimesInOrder(t *testing.T) {
+	jobs := []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0)},
+		{ID: "morning", Schedule: domain.NewDailySchedule(8, 0)},
+	}
+	now := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	upcoming := scheduler.Upcoming(jobs, now, 3)
+
+	assert.Len(t, upcoming, 3)
+	assert.Equal(t, "noon", upcoming[0].Job.ID)
+	assert.Equal(t, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC), upcoming[0].FireTime)
+	assert.Equal(t, "morning", upcoming[1].Job.ID)
+	assert.Equal(t, time.Date(2026, 2, 2, 8, 0, 0, 0, time.UTC), upcoming[1].FireTime)
+	assert.Equal(t, "noon", upcoming[2].Job.ID)
+}
+
+func TestUpcoming_NonPositiveCount_ReturnsNothing(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"}}
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, scheduler.Upcoming(jobs, from, 0))
+	assert.Empty(t, scheduler.Upcoming(jobs, from, -1))
+}
+
+func TestScheduler_Reload_ReplacesJobsAndKeepsPostRecords(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	oldPoster := &FakePoster{}
+	newPoster := &FakePoster{}
+	s := scheduler.New(clock, repo, oldPoster, []scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 0), Content: "Test post"},
+		{ID: "removed", Schedule: domain.NewDailySchedule(18, 0), Content: "Bye"},
+	})
+	s.RunOnce(context.Background())
+
+	diff := s.Reload([]scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 0), Content: "Edited post"},
+		{ID: "added", Schedule: domain.NewDailySchedule(12, 0), Content: "Hello"},
+	}, newPoster)
+	s.RunOnce(context.Background())
+
+	assert.Equal(t, []string{"added"}, diff.Added)
+	assert.Equal(t, []string{"removed"}, diff.Removed)
+	assert.Equal(t, []string{"daily-post"}, diff.Changed)
+	assert.Equal(t, 1, oldPoster.GetPostCount())
+	assert.Equal(t, 1, newPoster.GetPostCount())
+	assert.Len(t, s.Jobs(), 2)
+}
+
+func TestDiffJobs_IdenticalJobs_IsEmpty(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "a", Schedule: domain.NewDailySchedule(12, 0), Content: "x"}}
+	sameJobs := []scheduler.Job{{ID: "a", Schedule: domain.NewDailySchedule(12, 0), Content: "x"}}
+
+	diff := scheduler.DiffJobs(jobs, sameJobs)
+
+	assert.True(t, diff.IsEmpty())
+	assert.Equal(t, "no changes", diff.String())
+}
+
+func TestDiffJobs_ScheduleTimeChange_IsReportedAsChanged(t *testing.T) {
+	oldJobs := []scheduler.Job{{ID: "a", Schedule: domain.NewDailySchedule(12, 0), Content: "x"}}
+	newJobs := []scheduler.Job{{ID: "a", Schedule: domain.NewDailySchedule(12, 30), Content: "x"}}
+
+	diff := scheduler.DiffJobs(oldJobs, newJobs)
+
+	assert.Equal(t, "changed: a", diff.String())
+}
+
+func TestScheduler_Run_PostsWhenFireTimeArrives(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 37), Content: "Test post"},
+	})
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, time.Minute, timers.NextArmed(t))
+	for range 36 {
+		timers.Advance(time.Minute)
+		assert.Equal(t, time.Minute, timers.NextArmed(t))
+	}
+	assert.Equal(t, 0, poster.GetPostCount())
+
+	timers.Advance(time.Minute)
+	timers.NextArmed(t)
+
+	waitForPostCount(t, poster, 1)
+}
+
+func TestScheduler_Run_WakesExactlyAtNextFireTime(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 36, 20, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 37), Content: "Test post"},
+	})
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, 40*time.Second, timers.NextArmed(t))
+
+	timers.Advance(40 * time.Second)
+	timers.NextArmed(t)
+
+	waitForPostCount(t, poster, 1)
+}
+
+func TestScheduler_Run_PostsCloselySpacedJobs(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 59, 30, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "first", Schedule: domain.NewDailySchedule(12, 0), Content: "First"},
+		{ID: "same-time", Schedule: domain.NewDailySchedule(12, 0), Content: "Same time"},
+		{ID: "next-minute", Schedule: domain.NewDailySchedule(12, 1), Content: "Next minute"},
+	})
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, 30*time.Second, timers.NextArmed(t))
+	timers.Advance(30 * time.Second)
+	assert.Equal(t, time.Minute, timers.NextArmed(t))
+	waitForPostCount(t, poster, 2)
+
+	timers.Advance(time.Minute)
+	timers.NextArmed(t)
+	waitForPostCount(t, poster, 3)
+}
+
+func TestScheduler_Run_WallClockJump_SkipsMissedJobsAndRearms(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 59, 0, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+		{ID: "evening", Schedule: domain.NewDailySchedule(18, 0), Content: "Evening"},
+	})
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, time.Minute, timers.NextArmed(t))
+	timers.Advance(6*time.Hour + 30*time.Second)
+	assert.Equal(t, 30*time.Second, timers.NextArmed(t))
+	assert.Equal(t, 0, poster.GetPostCount())
+
+	timers.Advance(30 * time.Second)
+	timers.NextArmed(t)
+	waitForPostCount(t, poster, 1)
+}
+
+func TestScheduler_Run_WallClockJump_DetectedWithMonotonicClockReadings(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Now()}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	missed := clock.Now().Add(30 * time.Minute)
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "missed", Schedule: domain.NewDailySchedule(missed.Hour(), missed.Minute()), Content: "Missed"},
+	})
+	runScheduler(t, s, timers)
+
+	wait := timers.NextArmed(t)
+	timers.Advance(wait + 2*time.Hour)
+	timers.NextArmed(t)
+
+	assert.Never(t, func() bool { return poster.GetPostCount() > 0 }, 50*time.Millisecond, time.Millisecond)
+	assert.Equal(t, time.Duration(0), s.LoopLag())
+}
+
+type SlowPoster struct {
+	delay     time.Duration
+	active    int
+	maxActive int
+	postCount int
+	mutex     sync.Mutex
+}
+
+func (p *SlowPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.mutex.Lock()
+	p.active++
+	p.maxActive = max(p.maxActive, p.active)
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		p.active--
+		p.postCount++
+		p.mutex.Unlock()
+	}()
+
+	select {
+	case <-time.After(p.delay):
+		return domain.PostResult{}, nil
+	case <-ctx.Done():
+		return domain.PostResult{}, ctx.Err()
+	}
+}
+
+func TestScheduler_Run_RecordsLoopLag(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 58, 0, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	s := scheduler.New(clock, NewFakePostRecordRepository(), &FakePoster{}, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+	})
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, time.Minute, timers.NextArmed(t))
+	timers.Advance(time.Minute + 2*time.Second)
+	timers.NextArmed(t)
+
+	assert.Equal(t, 2*time.Second, s.LoopLag())
+}
+
+func TestScheduler_Run_HeartbeatCapsTimerWait(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 0, 0, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	beats := make(chan time.Time, 16)
+	s := scheduler.New(clock, NewFakePostRecordRepository(), &FakePoster{}, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+	})
+	s.SetHeartbeat(15*time.Second, func(now time.Time) { beats <- now })
+	_, started := s.HeartbeatAge()
+	assert.False(t, started)
+	runScheduler(t, s, timers)
+
+	assert.Equal(t, 15*time.Second, timers.NextArmed(t))
+	assert.Equal(t, clock.Now(), <-beats)
+	timers.Advance(15 * time.Second)
+	timers.NextArmed(t)
+	assert.Equal(t, clock.Now(), <-beats)
+
+	age, started := s.HeartbeatAge()
+	assert.True(t, started)
+	assert.Equal(t, time.Duration(0), age)
+}
+
+func TestScheduler_RunOnce_PostsDueJobsConcurrentlyUpToWorkerLimit(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &SlowPoster{delay: 20 * time.Millisecond}
+	var jobs []scheduler.Job
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		jobs = append(jobs, scheduler.Job{ID: id, Schedule: domain.NewDailySchedule(12, 0), Content: id})
+	}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, jobs)
+	s.SetWorkers(2)
+
+	s.RunOnce(context.Background())
+
+	assert.Equal(t, 5, poster.postCount)
+	assert.Equal(t, 2, poster.maxActive)
+}
+
+func TestScheduler_RunOnce_JobTimeout_RecordsFailureInHistory(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	repo := NewFakePostRecordRepository()
+	poster := &SlowPoster{delay: time.Minute}
+	s := scheduler.New(clock, repo, poster, []scheduler.Job{
+		{ID: "slow", Schedule: domain.NewDailySchedule(12, 0), Content: "Slow"},
+	})
+	s.SetJobTimeout(10 * time.Millisecond)
+
+	s.RunOnce(context.Background())
+
+	record, _ := repo.Find("slow")
+	assert.True(t, record.IsZero())
+	history, _ := repo.Query(ports.PostHistoryQuery{ScheduleID: "slow"})
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.PostStatusFailed, history[0].Status)
+	assert.Contains(t, history[0].Error, "deadline exceeded")
+}
+
+type FlakyPoster struct {
+	failures int
+}
+
+func (p *FlakyPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	if p.failures > 0 {
+		p.failures--
+		return domain.PostResult{}, errors.New("server error")
+	}
+	return domain.PostResult{ID: "note-1"}, nil
+}
+
+func TestScheduler_RunOnce_LogsAttemptsWithJobAttributes(t *testing.T) {
+	var output bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&output, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), &FlakyPoster{failures: 2}, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon", Account: "sub"},
+	})
+	for range 3 {
+		s.RunOnce(context.Background())
+	}
+
+	var entries []map[string]any
+	decoder := json.NewDecoder(&output)
+	for decoder.More() {
+		var entry map[string]any
+		assert.NoError(t, decoder.Decode(&entry))
+		entries = append(entries, entry)
+	}
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "Post failed", entries[0]["msg"])
+	assert.Equal(t, "noon", entries[0]["schedule_id"])
+	assert.Equal(t, "sub", entries[0]["account"])
+	assert.Equal(t, float64(1), entries[0]["attempt"])
+	assert.Equal(t, float64(2), entries[1]["attempt"])
+	assert.Equal(t, "Posted", entries[2]["msg"])
+	assert.Equal(t, "note-1", entries[2]["note_id"])
+	assert.Equal(t, float64(3), entries[2]["attempt"])
+}
+
+type OutagePoster struct {
+	down   bool
+	alerts []string
+	mutex  sync.Mutex
+}
+
+func (p *OutagePoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if post.ScheduleID == "" {
+		p.alerts = append(p.alerts, post.Text)
+		return domain.PostResult{}, nil
+	}
+	if p.down {
+		return domain.PostResult{}, errors.New("instance down")
+	}
+	return domain.PostResult{ID: "note-1"}, nil
+}
+
+func TestScheduler_RunOnce_AlertsAfterRepeatedFailuresAndOnRecovery(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &OutagePoster{down: true}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+	})
+	alerts := usecases.NewFailureAlertUseCase(clock)
+	alerts.SetConfig(usecases.FailureAlertConfig{To: "@admin", Visibility: "specified", Threshold: 2})
+	alerts.SetPoster(poster)
+	s.SetFailureAlerts(alerts)
+
+	for range 3 {
+		s.RunOnce(context.Background())
+	}
+	poster.down = false
+	s.RunOnce(context.Background())
+
+	assert.Equal(t, []string{
+		"@admin hijiki: posts to account default failed 2 times in a row (latest: noon): instance down",
+		"@admin hijiki: posts to account default are working again",
+	}, poster.alerts)
+}
+
+func TestScheduler_Trigger_ConcurrentCallsPostOnce(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC)}
+	poster := &SlowPoster{delay: 10 * time.Millisecond}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 0), Content: "Test post"},
+	})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Trigger(context.Background(), "daily-post", false)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, poster.postCount)
+}
+
+func TestScheduler_Trigger_DuringReload_StillPostsOnce(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &SlowPoster{delay: 20 * time.Millisecond}
+	jobs := []scheduler.Job{{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 0), Content: "Test post"}}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, jobs)
+
+	done := make(chan struct{})
+	go func() {
+		s.Trigger(context.Background(), "daily-post", false)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return poster.Active() == 1 }, time.Second, time.Millisecond)
+	s.Reload(jobs, poster)
+	s.RunOnce(context.Background())
+	<-done
+
+	assert.Equal(t, 1, poster.Count())
+}
+
+func (p *SlowPoster) Active() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.active
+}
+
+func (p *SlowPoster) Count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.postCount
+}
+
+func TestScheduler_Run_Shutdown_WaitsForInFlightPosts(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 59, 30, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	repo := NewFakePostRecordRepository()
+	poster := &SlowPoster{delay: 50 * time.Millisecond}
+	s := scheduler.New(clock, repo, poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+	})
+	cancel, done := startScheduler(s, timers)
+
+	timers.NextArmed(t)
+	timers.Advance(30 * time.Second)
+	assert.Eventually(t, func() bool { return poster.Active() == 1 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	record, _ := repo.Find("noon")
+	assert.Equal(t, domain.PostStatusPosted, record.Status)
+}
+
+func TestScheduler_Run_BusyWorkers_DoNotBlockTheLoop(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 59, 30, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &SlowPoster{delay: 100 * time.Millisecond}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "a", Schedule: domain.NewDailySchedule(12, 0), Content: "a"},
+		{ID: "b", Schedule: domain.NewDailySchedule(12, 0), Content: "b"},
+		{ID: "c", Schedule: domain.NewDailySchedule(12, 0), Content: "c"},
+	})
+	s.SetWorkers(1)
+	runScheduler(t, s, timers)
+
+	timers.NextArmed(t)
+	timers.Advance(30 * time.Second)
+	assert.Equal(t, time.Minute, timers.NextArmed(t))
+	assert.Equal(t, 0, poster.Count())
+
+	assert.Eventually(t, func() bool { return poster.Count() == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, poster.maxActive)
+}
+
+func TestScheduler_Run_Shutdown_InterruptsPostsAfterDrainTimeout(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 11, 59, 30, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	repo := NewFakePostRecordRepository()
+	poster := &SlowPoster{delay: time.Minute}
+	s := scheduler.New(clock, repo, poster, []scheduler.Job{
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "Noon"},
+	})
+	s.SetDrainTimeout(10 * time.Second)
+	cancel, done := startScheduler(s, timers)
+
+	timers.NextArmed(t)
+	timers.Advance(30 * time.Second)
+	assert.Eventually(t, func() bool { return poster.Active() == 1 }, time.Second, time.Millisecond)
+	timers.NextArmed(t)
+	cancel()
+
+	assert.Equal(t, 10*time.Second, timers.NextArmed(t))
+	timers.Advance(10 * time.Second)
+	<-done
+
+	history, _ := repo.Query(ports.PostHistoryQuery{ScheduleID: "noon"})
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.PostStatusFailed, history[0].Status)
+	assert.Contains(t, history[0].Error, "context canceled")
+}
+
+func TestScheduler_Run_DeferMuteWindow_PostsWhenWindowEnds(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 2, 59, 0, 0, time.UTC)}
+	timers := NewFakeTimerSource(clock)
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "night", Schedule: domain.NewDailySchedule(3, 0), Content: "Night"},
+	})
+	s.SetMuteWindows([]domain.MuteWindow{domain.NewMuteWindow(1, 0, 3, 30, domain.MutePolicyDefer)})
+	runScheduler(t, s, timers)
+
+	timers.NextArmed(t)
+	timers.Advance(time.Minute)
+	assert.Eventually(t, func() bool {
+		return s.States()[0].DeferredUntil.Equal(time.Date(2026, 2, 1, 3, 30, 0, 0, time.UTC))
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, poster.GetPostCount())
+
+	timers.Advance(30 * time.Minute)
+	waitForPostCount(t, poster, 1)
+	assert.Eventually(t, func() bool { return s.States()[0].DeferredUntil.IsZero() }, time.Second, time.Millisecond)
+}
+
+func TestScheduler_Pause_SkipsJobUntilResumed(t *testing.T) {
+	clock := &FakeClock{currentTime: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)}
+	poster := &FakePoster{}
+	s := scheduler.New(clock, NewFakePostRecordRepository(), poster, []scheduler.Job{
+		{ID: "daily-post", Schedule: domain.NewDailySchedule(12, 0), Content: "Test post"},
+	})
+
+	assert.ErrorIs(t, s.Pause("missing"), scheduler.ErrUnknownSchedule)
+	assert.NoError(t, s.Pause("daily-post"))
+	s.RunOnce(context.Background())
+	assert.Equal(t, 0, poster.GetPostCount())
+	assert.True(t, s.States()[0].Paused)
+
+	assert.NoError(t, s.Resume("daily-post"))
+	s.RunOnce(context.Background())
 	assert.Equal(t, 1, poster.GetPostCount())
 }
@@ -0,0 +1,275 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/application/ports"
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+)
+
+const (
+	SimulationStatusPosted   = "posted"
+	SimulationStatusSkipped  = "skipped"
+	SimulationStatusDeferred = "deferred"
+
+	HolidayDateLayout = "2006-01-02"
+)
+
+type SimulationOptions struct {
+	MuteWindows []domain.MuteWindow
+	Pauses      domain.PauseState
+	Holidays    map[string]string
+}
+
+type SimulationEvent struct {
+	ScheduleID    string
+	ScheduledAt   time.Time
+	Status        string
+	Reason        string
+	Note          string
+	Holiday       bool
+	HolidayName   string
+	DeferredUntil time.Time
+	Post          domain.Post
+}
+
+type simulationEventJSON struct {
+	ScheduleID     string     `json:"scheduleId"`
+	ScheduledAt    time.Time  `json:"scheduledAt"`
+	Status         string     `json:"status"`
+	Reason         string     `json:"reason,omitempty"`
+	Note           string     `json:"note,omitempty"`
+	Holiday        bool       `json:"holiday,omitempty"`
+	HolidayName    string     `json:"holidayName,omitempty"`
+	DeferredUntil  *time.Time `json:"deferredUntil,omitempty"`
+	Account        string     `json:"account,omitempty"`
+	Text           string     `json:"text"`
+	SpoilerText    string     `json:"spoilerText,omitempty"`
+	Visibility     string     `json:"visibility,omitempty"`
+	MediaPaths     []string   `json:"media,omitempty"`
+	IdempotencyKey string     `json:"idempotencyKey,omitempty"`
+}
+
+func (e SimulationEvent) MarshalJSON() ([]byte, error) {
+	var deferredUntil *time.Time
+	if !e.DeferredUntil.IsZero() {
+		deferredUntil = &e.DeferredUntil
+	}
+	return json.Marshal(simulationEventJSON{
+		ScheduleID:     e.ScheduleID,
+		ScheduledAt:    e.ScheduledAt,
+		Status:         e.Status,
+		Reason:         e.Reason,
+		Note:           e.Note,
+		Holiday:        e.Holiday,
+		HolidayName:    e.HolidayName,
+		DeferredUntil:  deferredUntil,
+		Account:        e.Post.Account,
+		Text:           e.Post.Text,
+		SpoilerText:    e.Post.SpoilerText,
+		Visibility:     e.Post.Visibility,
+		MediaPaths:     e.Post.MediaPaths,
+		IdempotencyKey: e.Post.IdempotencyKey,
+	})
+}
+
+type simulationClock struct {
+	currentTime time.Time
+	mutex       sync.RWMutex
+}
+
+func (c *simulationClock) Now() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.currentTime
+}
+
+func (c *simulationClock) set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.currentTime = t
+}
+
+type simulationPoster struct {
+	posts map[string]domain.Post
+	mutex sync.Mutex
+}
+
+func (p *simulationPoster) Post(ctx context.Context, post domain.Post) (domain.PostResult, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.posts[post.ScheduleID] = post
+	return domain.PostResult{}, nil
+}
+
+func (p *simulationPoster) takePosts() map[string]domain.Post {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	posts := p.posts
+	p.posts = make(map[string]domain.Post)
+	return posts
+}
+
+func Simulate(repository ports.PostRecordRepository, jobs []Job, from, to time.Time, options SimulationOptions) []SimulationEvent {
+	clock := &simulationClock{}
+	poster := &simulationPoster{posts: make(map[string]domain.Post)}
+	s := New(clock, repository, poster, jobs)
+	s.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.SetMuteWindows(options.MuteWindows)
+	s.SetPauseStore(&memoryPauseStore{state: options.Pauses})
+	guard := domain.NewPostGuard()
+
+	var events []SimulationEvent
+	lastOffsets := make(map[string]int, len(jobs))
+	cursor := from.Add(-time.Nanosecond)
+	for {
+		fireTime, dueJobs := nextDueJobs(jobs, cursor)
+		deferred := earliestDeferredEntries(s.deferredEntries(), cursor)
+		switch {
+		case len(deferred) == 0:
+		case len(dueJobs) == 0 || deferred[0].fireTime.Before(fireTime):
+			fireTime, dueJobs = deferred[0].fireTime, nil
+		case deferred[0].fireTime.After(fireTime):
+			deferred = nil
+		}
+		if (len(dueJobs) == 0 && len(deferred) == 0) || !fireTime.Before(to) || !fireTime.After(cursor) {
+			return events
+		}
+
+		clock.set(fireTime)
+		_, useCase := s.snapshot()
+		for _, entry := range deferred {
+			s.execute(context.Background(), useCase, entry)
+			s.clearDeferred(entry)
+			event := deferredSimulationEvent(entry, poster.takePosts())
+			event.HolidayName, event.Holiday = options.Holidays[event.ScheduledAt.Format(HolidayDateLayout)]
+			events = append(events, event)
+		}
+
+		recordsBeforeRun := findRecords(repository, dueJobs)
+		s.RunOnce(context.Background())
+		posts := poster.takePosts()
+		deferrals := s.deferredEntries()
+
+		for _, job := range dueJobs {
+			event := simulationEventFor(job, fireTime, posts, recordsBeforeRun[job.ID], guard, options)
+			for _, entry := range deferrals {
+				if entry.job.ID == job.ID && entry.scheduledAt.Equal(fireTime) {
+					event.Status = SimulationStatusDeferred
+					event.DeferredUntil = entry.fireTime
+					event.Reason += ", deferred until " + entry.fireTime.Format(time.RFC3339)
+				}
+			}
+			event.Note = utcOffsetChangeNote(lastOffsets, job.ID, fireTime)
+			event.HolidayName, event.Holiday = options.Holidays[fireTime.Format(HolidayDateLayout)]
+			events = append(events, event)
+		}
+		cursor = fireTime
+	}
+}
+
+func earliestDeferredEntries(entries []fireEntry, cursor time.Time) []fireEntry {
+	var earliest []fireEntry
+	for _, entry := range entries {
+		switch {
+		case !entry.fireTime.After(cursor):
+		case len(earliest) == 0 || entry.fireTime.Before(earliest[0].fireTime):
+			earliest = []fireEntry{entry}
+		case entry.fireTime.Equal(earliest[0].fireTime):
+			earliest = append(earliest, entry)
+		}
+	}
+	return earliest
+}
+
+func deferredSimulationEvent(entry fireEntry, posts map[string]domain.Post) SimulationEvent {
+	event := SimulationEvent{
+		ScheduleID:  entry.job.ID,
+		ScheduledAt: entry.fireTime,
+		Status:      SimulationStatusPosted,
+		Reason:      "deferred from " + entry.scheduledAt.Format(time.RFC3339),
+	}
+	post, posted := posts[entry.job.ID]
+	if !posted {
+		event.Status = SimulationStatusSkipped
+		post = entry.job.Post()
+		post.ScheduleID = entry.job.ID
+	}
+	event.Post = post
+	return event
+}
+
+func nextDueJobs(jobs []Job, cursor time.Time) (time.Time, []Job) {
+	var earliest time.Time
+	var dueJobs []Job
+	for _, job := range jobs {
+		nextTime := job.Schedule.NextTime(cursor)
+		switch {
+		case len(dueJobs) == 0 || nextTime.Before(earliest):
+			earliest = nextTime
+			dueJobs = []Job{job}
+		case nextTime.Equal(earliest):
+			dueJobs = append(dueJobs, job)
+		}
+	}
+	sort.SliceStable(dueJobs, func(i, j int) bool { return dueJobs[i].ID < dueJobs[j].ID })
+	return earliest, dueJobs
+}
+
+func findRecords(repository ports.PostRecordRepository, jobs []Job) map[string]domain.PostRecord {
+	records := make(map[string]domain.PostRecord, len(jobs))
+	for _, job := range jobs {
+		record, err := repository.Find(job.ID)
+		if err == nil {
+			records[job.ID] = record
+		}
+	}
+	return records
+}
+
+func simulationEventFor(job Job, fireTime time.Time, posts map[string]domain.Post, record domain.PostRecord, guard *domain.PostGuard, options SimulationOptions) SimulationEvent {
+	event := SimulationEvent{ScheduleID: job.ID, ScheduledAt: fireTime}
+
+	post, posted := posts[job.ID]
+	if posted {
+		event.Status = SimulationStatusPosted
+		event.Post = post
+		return event
+	}
+
+	event.Status = SimulationStatusSkipped
+	event.Post = job.Post()
+	event.Post.ScheduleID = job.ID
+	window, muted := domain.ActiveMuteWindow(options.MuteWindows, fireTime)
+	switch {
+	case !guard.CanPost(job.Schedule, record, fireTime):
+		event.Reason = "already posted in this period at " + record.LastPostedAt.Format(time.RFC3339)
+	case options.Pauses.IsPaused(job.ID):
+		event.Reason = "paused"
+	case muted:
+		event.Reason = "muted during " + window.String()
+	default:
+		event.Reason = "not executed by scheduler"
+	}
+	return event
+}
+
+func utcOffsetChangeNote(lastOffsets map[string]int, scheduleID string, fireTime time.Time) string {
+	_, offset := fireTime.Zone()
+	lastOffset, seen := lastOffsets[scheduleID]
+	lastOffsets[scheduleID] = offset
+	if !seen || lastOffset == offset {
+		return ""
+	}
+	return "UTC offset changed from " + formatUTCOffset(lastOffset) + " to " + formatUTCOffset(offset)
+}
+
+func formatUTCOffset(offsetSeconds int) string {
+	return time.Unix(0, 0).In(time.FixedZone("", offsetSeconds)).Format("-07:00")
+}
@@ -0,0 +1,169 @@
+package scheduler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/CAT5NEKO/hijikiTool/internal/domain"
+	"github.com/CAT5NEKO/hijikiTool/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate_DailyJob_PostsOncePerDayInRange(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "daily-hijiki", Schedule: domain.NewDailySchedule(12, 37), Content: "ひじき"}}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 4, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{})
+
+	require.Len(t, events, 3)
+	for i, event := range events {
+		assert.Equal(t, scheduler.SimulationStatusPosted, event.Status)
+		assert.Equal(t, time.Date(2026, 12, 1+i, 12, 37, 0, 0, time.UTC), event.ScheduledAt)
+		assert.Equal(t, "ひじき", event.Post.Text)
+		assert.Equal(t, "daily-hijiki", event.Post.ScheduleID)
+	}
+}
+
+func TestSimulate_IncludesJobScheduledExactlyAtStart(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "new-year", Schedule: domain.NewYearlySchedule(time.January, 1, 0, 0), Content: "ミレニアム！"}}
+	from := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{})
+
+	require.Len(t, events, 1)
+	assert.Equal(t, from, events[0].ScheduledAt)
+}
+
+func TestSimulate_JobsSharingFireTime_AreAllReportedInIDOrder(t *testing.T) {
+	jobs := []scheduler.Job{
+		{ID: "b-job", Schedule: domain.NewDailySchedule(12, 0), Content: "B"},
+		{ID: "a-job", Schedule: domain.NewDailySchedule(12, 0), Content: "A"},
+	}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 2, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{})
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "a-job", events[0].ScheduleID)
+	assert.Equal(t, "b-job", events[1].ScheduleID)
+}
+
+func TestSimulate_WhenAlreadyPostedInPeriod_ReportsPostGuardSkip(t *testing.T) {
+	repo := NewFakePostRecordRepository()
+	require.NoError(t, repo.Save(domain.NewPostRecord("monthly", time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC))))
+	jobs := []scheduler.Job{{ID: "monthly", Schedule: domain.NewMonthlySchedule(15, 9, 0), Content: "月次"}}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(repo, jobs, from, to, scheduler.SimulationOptions{})
+
+	require.Len(t, events, 2)
+	assert.Equal(t, scheduler.SimulationStatusSkipped, events[0].Status)
+	assert.Contains(t, events[0].Reason, "already posted in this period")
+	assert.Equal(t, scheduler.SimulationStatusPosted, events[1].Status)
+}
+
+func TestSimulate_AcrossDSTTransition_NotesUTCOffsetChange(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	jobs := []scheduler.Job{{ID: "daily", Schedule: domain.NewDailySchedule(12, 0), Content: "DST"}}
+	from := time.Date(2026, 3, 28, 0, 0, 0, 0, berlin)
+	to := time.Date(2026, 3, 30, 0, 0, 0, 0, berlin)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{})
+
+	require.Len(t, events, 2)
+	assert.Empty(t, events[0].Note)
+	assert.Equal(t, "UTC offset changed from +01:00 to +02:00", events[1].Note)
+	assert.Equal(t, 12, events[1].ScheduledAt.Hour())
+}
+
+func TestSimulate_MarksPostsOnHolidays(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "daily", Schedule: domain.NewDailySchedule(9, 0), Content: "おはよう"}}
+	from := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2027, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{
+		Holidays: map[string]string{"2027-01-01": "元日", "2027-01-02": ""},
+	})
+
+	require.Len(t, events, 3)
+	assert.False(t, events[0].Holiday)
+	assert.True(t, events[1].Holiday)
+	assert.Equal(t, "元日", events[1].HolidayName)
+	assert.True(t, events[2].Holiday)
+	assert.Empty(t, events[2].HolidayName)
+	assert.Equal(t, scheduler.SimulationStatusPosted, events[1].Status)
+}
+
+func TestSimulationEvent_MarshalJSON_FlattensRenderedPost(t *testing.T) {
+	event := scheduler.SimulationEvent{
+		ScheduleID:  "daily",
+		ScheduledAt: time.Date(2026, 12, 1, 12, 0, 0, 0, time.UTC),
+		Status:      scheduler.SimulationStatusPosted,
+		Post:        domain.Post{ScheduleID: "daily", Account: "mastodon", Text: "おひ"},
+	}
+
+	data, err := json.Marshal(event)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"scheduleId":"daily","scheduledAt":"2026-12-01T12:00:00Z","status":"posted","account":"mastodon","text":"おひ"}`, string(data))
+}
+
+func TestSimulate_SkipMuteWindowAndPause_ReportSkipped(t *testing.T) {
+	jobs := []scheduler.Job{
+		{ID: "night", Schedule: domain.NewDailySchedule(3, 0), Content: "夜"},
+		{ID: "noon", Schedule: domain.NewDailySchedule(12, 0), Content: "昼"},
+	}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 2, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{
+		MuteWindows: []domain.MuteWindow{domain.NewMuteWindow(1, 0, 6, 0, domain.MutePolicySkip)},
+		Pauses:      domain.PauseState{}.WithSchedule("noon", true),
+	})
+
+	require.Len(t, events, 2)
+	assert.Equal(t, scheduler.SimulationStatusSkipped, events[0].Status)
+	assert.Equal(t, "muted during 01:00-06:00", events[0].Reason)
+	assert.Equal(t, scheduler.SimulationStatusSkipped, events[1].Status)
+	assert.Equal(t, "paused", events[1].Reason)
+}
+
+func TestSimulate_DeferMuteWindow_ReportsDeferralAndLaterPost(t *testing.T) {
+	jobs := []scheduler.Job{{ID: "nightly", Schedule: domain.NewDailySchedule(23, 30), Content: "おやすみ"}}
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 3, 0, 0, 0, 0, time.UTC)
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, from, to, scheduler.SimulationOptions{
+		MuteWindows: []domain.MuteWindow{domain.NewMuteWindow(23, 0, 6, 0, domain.MutePolicyDefer)},
+	})
+
+	require.Len(t, events, 3)
+	assert.Equal(t, scheduler.SimulationStatusDeferred, events[0].Status)
+	assert.Equal(t, time.Date(2026, 12, 2, 6, 0, 0, 0, time.UTC), events[0].DeferredUntil)
+	assert.Equal(t, scheduler.SimulationStatusPosted, events[1].Status)
+	assert.Equal(t, time.Date(2026, 12, 2, 6, 0, 0, 0, time.UTC), events[1].ScheduledAt)
+	assert.Equal(t, "deferred from 2026-12-01T23:30:00Z", events[1].Reason)
+	assert.Equal(t, scheduler.SimulationStatusDeferred, events[2].Status)
+}
+
+func TestSimulate_DoesNotWriteSchedulerLogs(t *testing.T) {
+	var output bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&output, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+	jobs := []scheduler.Job{{ID: "daily", Schedule: domain.NewDailySchedule(12, 0), Content: "ひじき"}}
+
+	events := scheduler.Simulate(NewFakePostRecordRepository(), jobs, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 2, 0, 0, 0, 0, time.UTC), scheduler.SimulationOptions{})
+
+	require.Len(t, events, 1)
+	assert.Empty(t, output.String())
+}
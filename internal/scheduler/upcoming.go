@@ -0,0 +1,30 @@
+package scheduler
+
+import "time"
+
+type UpcomingPost struct {
+	Job      Job
+	FireTime time.Time
+}
+
+func Upcoming(jobs []Job, from time.Time, count int) []UpcomingPost {
+	if count < 1 {
+		return nil
+	}
+	upcoming := make([]UpcomingPost, 0, count)
+	cursor := from
+	for len(upcoming) < count {
+		fireTime, dueJobs := nextDueJobs(jobs, cursor)
+		if len(dueJobs) == 0 || !fireTime.After(cursor) {
+			return upcoming
+		}
+		for _, job := range dueJobs {
+			if len(upcoming) == count {
+				break
+			}
+			upcoming = append(upcoming, UpcomingPost{Job: job, FireTime: fireTime})
+		}
+		cursor = fireTime
+	}
+	return upcoming
+}
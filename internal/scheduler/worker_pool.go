@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type workerPool struct {
+	slots    chan struct{}
+	running  sync.WaitGroup
+	mutex    sync.Mutex
+	inFlight map[string]int
+}
+
+func newWorkerPool(size int) *workerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &workerPool{slots: make(chan struct{}, size), inFlight: make(map[string]int)}
+}
+
+func (p *workerPool) submit(ctx context.Context, name string, task func()) {
+	p.running.Add(1)
+	p.track(name, 1)
+	go func() {
+		defer func() {
+			p.track(name, -1)
+			p.running.Done()
+		}()
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-p.slots }()
+		task()
+	}()
+}
+
+func (p *workerPool) track(name string, delta int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.inFlight[name] += delta
+	if p.inFlight[name] <= 0 {
+		delete(p.inFlight, name)
+	}
+}
+
+func (p *workerPool) names() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	names := make([]string, 0, len(p.inFlight))
+	for name := range p.inFlight {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *workerPool) wait() {
+	p.running.Wait()
+}
+
+func (p *workerPool) waitUntil(deadline <-chan time.Time) bool {
+	done := make(chan struct{})
+	go func() {
+		p.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-deadline:
+		return false
+	}
+}